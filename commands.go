@@ -0,0 +1,370 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-proverbs/go-proverbs/internal/export"
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+	"github.com/go-proverbs/go-proverbs/internal/web"
+)
+
+// command is a subcommand run instead of the server
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) int
+}
+
+var commands = []command{
+	{"check", "validate the proverbs and their examples", runCheck},
+	{"export", "write the collection as a static site, Markdown book, EPUB, single HTML file or fortune file", runExport},
+	{"fortune", "print a random proverb, like fortune(6)", runFortune},
+	{"fmt", "list, diff or rewrite examples that are not gofmt-formatted", runFmt},
+	{"verify", "run every example and compare its output with its // Output: trailer", runVerify},
+}
+
+// runCommand runs the named subcommand and returns its exit code
+func runCommand(name string, args []string) int {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd.run(args)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	printUsage(os.Stderr)
+	return 2
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s [command] [flags]\n\n", os.Args[0])
+	fmt.Fprintln(w, "Without a command the web server is started.")
+	fmt.Fprintln(w, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+// runCheck validates the collection and lists every problem, exiting with
+// 1 if there is any
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	examplesOnly := flags.Bool("examples", false, "only check for missing, orphan and empty examples, without compiling them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	collection := loadCollection()
+	var errs []proverbs.ValidationError
+	if *examplesOnly {
+		errs = collection.ValidateExamples()
+	} else {
+		errs = collection.ValidateCollection()
+	}
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		fmt.Printf("FAIL\n%d problems found\n", len(errs))
+		return 1
+	}
+	fmt.Println("ok")
+	return 0
+}
+
+// runFmt formats the example files of the data directory, listing those
+// that change by default. Templates whose actions keep them from parsing
+// are reported as skipped, as check covers their expanded example. It exits
+// with 1 if a file is not formatted, unless it was rewritten, or does not
+// parse.
+func runFmt(args []string) int {
+	flags := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	dir := flags.String("dir", cmp.Or(os.Getenv("PROVERBS_DATA_DIR"), "internal/proverbs/examples"), "data directory holding the official and community examples")
+	diff := flags.Bool("d", false, "print the changes formatting would make")
+	write := flags.Bool("w", false, "rewrite the files in place")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	var files []string
+	for _, source := range []proverbs.Source{proverbs.SourceOfficial, proverbs.SourceCommunity} {
+		matches, err := filepath.Glob(filepath.Join(*dir, string(source), "*"+proverbs.ExampleExt))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		files = append(files, matches...)
+	}
+
+	status := 0
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		formatted, err := proverbs.FormatExample(string(src))
+		if err != nil {
+			if strings.Contains(string(src), "{{") {
+				fmt.Fprintf(os.Stderr, "%s: skipped: template actions keep it from parsing\n", file)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", file, err)
+				status = 1
+			}
+			continue
+		}
+		if formatted == string(src) {
+			continue
+		}
+
+		fmt.Println(file)
+		if *diff {
+			fmt.Print(formatDiff(string(src), formatted))
+		}
+		if !*write {
+			status = 1
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+		if err := os.WriteFile(file, []byte(formatted), info.Mode().Perm()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 2
+		}
+	}
+	return status
+}
+
+// formatDiff lists the lines formatting removes and adds, numbered by their
+// line in the original
+func formatDiff(src, formatted string) string {
+	var b strings.Builder
+	line := 1
+	for _, d := range proverbs.DiffLines(strings.Split(src, "\n"), strings.Split(formatted, "\n")) {
+		switch d.Op {
+		case proverbs.DiffRemove:
+			fmt.Fprintf(&b, "%6d - %s\n", line, d.Text)
+			line++
+		case proverbs.DiffAdd:
+			fmt.Fprintf(&b, "%6s + %s\n", "", d.Text)
+		default:
+			line++
+		}
+	}
+	return b.String()
+}
+
+// runVerify runs the examples that declare their output and reports those
+// whose output differs, exiting with 1 if any does
+func runVerify(args []string) int {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	pattern := flags.String("run", "", "only verify examples whose proverb ID matches this regular expression")
+	timeout := flags.Duration("timeout", proverbs.DefaultRunTimeout, "time limit for building and running each example")
+	verbose := flags.Bool("v", false, "also list passing examples")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	match, err := regexp.Compile(*pattern)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid -run pattern: %v\n", err)
+		return 2
+	}
+
+	collection := loadCollection()
+	var passed, failed, skipped int
+	for _, proverb := range collection.GetAll() {
+		if !match.MatchString(proverb.ID) {
+			continue
+		}
+
+		v, err := proverbs.VerifyExample(context.Background(), proverb, proverbs.RunOptions{Timeout: *timeout})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", proverb.ID, err)
+			return 1
+		}
+		if v == nil {
+			skipped++
+			continue
+		}
+
+		elapsed := time.Duration(v.Result.Duration) * time.Millisecond
+		if v.Passed {
+			passed++
+			if *verbose {
+				fmt.Printf("--- PASS: %s (%.2fs)\n", v.ID, elapsed.Seconds())
+			}
+			continue
+		}
+
+		failed++
+		fmt.Printf("--- FAIL: %s (%.2fs)\n", v.ID, elapsed.Seconds())
+		switch {
+		case v.Result.Stage == "build":
+			fmt.Printf("    build failed:\n%s", indent(v.Result.Stderr, "        "))
+		case v.Result.TimedOut:
+			fmt.Printf("    timed out after %s\n", *timeout)
+		case v.Result.ExitCode != 0:
+			fmt.Printf("    exit status %d:\n%s", v.Result.ExitCode, indent(v.Result.Stderr, "        "))
+		}
+		fmt.Printf("    output (-want +got):\n%s", indent(v.Diff, "        "))
+	}
+
+	status := "PASS"
+	if failed > 0 {
+		status = "FAIL"
+	}
+	fmt.Printf("%s\nverified %d examples: %d passed, %d failed, %d skipped\n",
+		status, passed+failed, passed, failed, skipped)
+	if failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// runExport writes the collection in the format given by -format to the
+// output directory
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", "site", "output format: site, book, epub, html or fortune")
+	out := flags.String("o", "dist", "output directory")
+	base := flags.String("base", "/", "URL path the site is served from, e.g. /go-proverbs/")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	collection := loadCollection()
+	var err error
+	switch *format {
+	case "site":
+		err = exportSite(collection, *out, *base)
+	case "book":
+		err = export.Book(collection, *out)
+	case "epub":
+		err = exportFile(filepath.Join(*out, "go-proverbs.epub"), collection, export.EPUB)
+	case "html":
+		err = exportFile(filepath.Join(*out, "go-proverbs.html"), collection, export.HTML)
+	case "fortune":
+		err = export.Fortune(collection, *out)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown export format %q\n", *format)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+		return 1
+	}
+	fmt.Printf("exported %s to %s\n", *format, *out)
+	return 0
+}
+
+// exportSite renders the pages and API responses the server would serve
+// for store into dir, with the embedded templates and static files
+func exportSite(store proverbs.Store, dir, base string) error {
+	// Rendering logs every page at info level
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	files := webFiles(false)
+	webHandler := web.NewHandler(store, logger, files)
+	webHandler.RenderStatic()
+	mux, err := newServeMux(store, webHandler, files)
+	if err != nil {
+		return err
+	}
+	static, err := fs.Sub(files, "static")
+	if err != nil {
+		return err
+	}
+	return export.Site(mux, store, static, dir, base)
+}
+
+// exportFile writes the file an export function produces for store,
+// creating its directory and removing the file if the export fails
+func exportFile(name string, store proverbs.Store, write func(proverbs.Store, io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(store, f); err != nil {
+		f.Close()
+		os.Remove(name)
+		return err
+	}
+	return f.Close()
+}
+
+// runFortune prints a random proverb wrapped to the terminal, optionally
+// drawn from one category or tag. It exits with 1 if none matches.
+func runFortune(args []string) int {
+	flags := flag.NewFlagSet("fortune", flag.ContinueOnError)
+	category := flags.String("category", "", "only pick proverbs of this category")
+	tag := flags.String("tag", "", "only pick proverbs with this tag")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	collection := loadCollection()
+	var candidates []proverbs.Proverb
+	switch {
+	case *category != "" && *tag != "":
+		tagged := collection.GetByTag(*tag)
+		for _, p := range collection.GetByCategory(proverbs.Category(*category)) {
+			if slices.ContainsFunc(tagged, func(t proverbs.Proverb) bool { return t.ID == p.ID }) {
+				candidates = append(candidates, p)
+			}
+		}
+	case *category != "":
+		candidates = collection.GetByCategory(proverbs.Category(*category))
+	case *tag != "":
+		candidates = collection.GetByTag(*tag)
+	default:
+		candidates = collection.GetAll()
+	}
+	if len(candidates) == 0 {
+		fmt.Fprintln(os.Stderr, "no proverbs match")
+		return 1
+	}
+
+	p := candidates[rand.IntN(len(candidates))]
+	fmt.Print(export.FormatFortune(p, terminalWidth()))
+	return 0
+}
+
+// terminalWidth returns the width of the terminal standard output is
+// connected to, $COLUMNS when it is not a terminal, or 80
+func terminalWidth() int {
+	if width := ttyWidth(os.Stdout); width > 0 {
+		return width
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return 80
+}
+
+// indent prefixes every line of s
+func indent(s, prefix string) string {
+	s = strings.TrimRight(s, "\n")
+	if s == "" {
+		return ""
+	}
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix) + "\n"
+}
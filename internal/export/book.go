@@ -0,0 +1,272 @@
+package export
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+// bookTitle is the title of every book the collection is exported as
+const bookTitle = "Go Proverbs"
+
+// bookSources are the parts of a book, in order
+var bookSources = []proverbs.Source{proverbs.SourceOfficial, proverbs.SourceCommunity}
+
+// chapterGroup is the proverbs of one category from one source
+type chapterGroup struct {
+	Source   proverbs.Source
+	Category proverbs.Category
+	Proverbs []proverbs.Proverb
+}
+
+// chapterGroups groups proverbs by source, then by category in name order,
+// keeping the order of the proverbs within a category
+func chapterGroups(all []proverbs.Proverb) []chapterGroup {
+	var groups []chapterGroup
+	for _, source := range bookSources {
+		byCategory := make(map[proverbs.Category][]proverbs.Proverb)
+		for _, p := range all {
+			if p.Source == source {
+				byCategory[p.Category] = append(byCategory[p.Category], p)
+			}
+		}
+		for _, category := range slices.Sorted(maps.Keys(byCategory)) {
+			groups = append(groups, chapterGroup{Source: source, Category: category, Proverbs: byCategory[category]})
+		}
+	}
+	return groups
+}
+
+// sourceTitle returns the heading of a source's part of a book
+func sourceTitle(source proverbs.Source) string {
+	return strings.Title(string(source)) + " Proverbs"
+}
+
+// categoryTitle returns a category as it is shown in headings
+func categoryTitle(category proverbs.Category) string {
+	return strings.ReplaceAll(strings.Title(string(category)), "-", " ")
+}
+
+// Book writes the proverbs in store into dir as a Markdown book that builds
+// with mdBook or GitBook as is. The chapters are in dir/src: a README.md
+// introduction, SUMMARY.md as the table of contents, one part per source
+// with a chapter per category and a sub-chapter per proverb, and tags.md
+// indexing the proverbs by tag. book.toml configures mdBook, book.json and
+// .gitbook.yaml GitBook.
+func Book(store proverbs.Store, dir string) error {
+	all, err := store.List()
+	if err != nil {
+		return err
+	}
+	groups := chapterGroups(all)
+	src := filepath.Join(dir, "src")
+
+	files := map[string]string{
+		filepath.Join(dir, "book.toml"):     fmt.Sprintf("[book]\ntitle = %q\nlanguage = \"en\"\nsrc = \"src\"\n", bookTitle),
+		filepath.Join(dir, "book.json"):     fmt.Sprintf("{\n  \"title\": %q,\n  \"root\": \"./src\"\n}\n", bookTitle),
+		filepath.Join(dir, ".gitbook.yaml"): "root: ./src/\n\nstructure:\n  readme: README.md\n  summary: SUMMARY.md\n",
+		filepath.Join(src, "README.md"):     bookIntro(all),
+		filepath.Join(src, "SUMMARY.md"):    bookSummary(groups),
+		filepath.Join(src, "tags.md"):       bookTags(all),
+	}
+	for _, group := range groups {
+		files[filepath.Join(src, filepath.FromSlash(categoryPath(group)))] = categoryChapter(group)
+		for _, p := range group.Proverbs {
+			files[filepath.Join(src, filepath.FromSlash(chapterPath(p)))] = proverbChapter(p)
+		}
+	}
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		rel, err := filepath.Rel(dir, file)
+		if err != nil || !filepath.IsLocal(rel) {
+			return fmt.Errorf("chapter %s leaves the output directory", file)
+		}
+		if err := writeOut(file, []byte(files[file])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// categoryPath returns the path of a category's chapter within the book
+func categoryPath(group chapterGroup) string {
+	return path.Join(string(group.Source), string(group.Category), "README.md")
+}
+
+// chapterPath returns the path of a proverb's chapter within the book
+func chapterPath(p proverbs.Proverb) string {
+	return path.Join(string(p.Source), string(p.Category), p.ID+".md")
+}
+
+// bookIntro renders the introduction chapter
+func bookIntro(all []proverbs.Proverb) string {
+	stats := proverbs.ComputeStats(all)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", bookTitle)
+	b.WriteString("A collection of Go programming wisdom: the official Go proverbs from Rob Pike's talk at Gopherfest SV 2015, and proverbs contributed by the community.\n\n")
+	fmt.Fprintf(&b, "This book holds %d proverbs, %d official and %d from the community, each with an explanation and an example. ", stats.Total, stats.Official, stats.Community)
+	b.WriteString("The proverbs are grouped by source and category; the [tag index](tags.md) lists them by topic.\n")
+	return b.String()
+}
+
+// bookSummary renders the table of contents
+func bookSummary(groups []chapterGroup) string {
+	var b strings.Builder
+	b.WriteString("# Summary\n\n[Introduction](README.md)\n")
+	var source proverbs.Source
+	for _, group := range groups {
+		if group.Source != source {
+			source = group.Source
+			fmt.Fprintf(&b, "\n# %s\n\n", sourceTitle(source))
+		}
+		fmt.Fprintf(&b, "- [%s](%s)\n", escapeLinkText(categoryTitle(group.Category)), categoryPath(group))
+		for _, p := range group.Proverbs {
+			fmt.Fprintf(&b, "  - [%s](%s)\n", escapeLinkText(p.Title), chapterPath(p))
+		}
+	}
+	b.WriteString("\n# Index\n\n- [Tags](tags.md)\n")
+	return b.String()
+}
+
+// categoryChapter renders the chapter introducing a category, listing its
+// proverbs
+func categoryChapter(group chapterGroup) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", categoryTitle(group.Category))
+	fmt.Fprintf(&b, "%s about %s:\n\n", sourceTitle(group.Source), strings.ReplaceAll(string(group.Category), "-", " "))
+	for _, p := range group.Proverbs {
+		fmt.Fprintf(&b, "- [%s](%s.md) — %s\n", escapeLinkText(p.Title), p.ID, p.Text)
+	}
+	return b.String()
+}
+
+// proverbChapter renders a proverb's chapter. Its tags link to the index,
+// two directories up.
+func proverbChapter(p proverbs.Proverb) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	fmt.Fprintf(&b, "> %s\n", p.Text)
+	if p.Author != "" {
+		fmt.Fprintf(&b, ">\n> — %s\n", p.Author)
+	}
+	if p.Explanation != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(p.Explanation))
+	}
+
+	if sections := exampleSections(p); len(sections) > 0 {
+		b.WriteString("\n## Example\n")
+		if v := p.MinGoVersion(); v != "" {
+			fmt.Fprintf(&b, "\nRequires Go %s or later.\n", v)
+		}
+		for _, section := range sections {
+			if heading := sectionHeading(section); heading != "" {
+				fmt.Fprintf(&b, "\n### %s\n", heading)
+			}
+			fmt.Fprintf(&b, "\n%s\n", fencedCode("go", section.Code))
+		}
+		if output, _, _ := proverbs.ExpectedOutput(p.Example); output != "" {
+			fmt.Fprintf(&b, "\nOutput:\n\n%s\n", fencedCode("text", strings.TrimRight(output, "\n")))
+		}
+	}
+
+	if len(p.Tags) > 0 {
+		links := make([]string, len(p.Tags))
+		for i, tag := range p.Tags {
+			links[i] = fmt.Sprintf("[%s](../../tags.md#%s)", escapeLinkText(tag), headingID(tag))
+		}
+		fmt.Fprintf(&b, "\n**Tags:** %s\n", strings.Join(links, ", "))
+	}
+	return b.String()
+}
+
+// exampleSections returns the sections of a proverb's example that have
+// code
+func exampleSections(p proverbs.Proverb) []proverbs.ExampleSection {
+	example := p.ExampleParts
+	if example == nil {
+		example = proverbs.ParseExample(p.Example)
+	}
+	if example == nil {
+		return nil
+	}
+	return slices.DeleteFunc(slices.Clone(example.Sections), func(s proverbs.ExampleSection) bool {
+		return strings.TrimSpace(s.Code) == ""
+	})
+}
+
+// sectionHeading returns the heading shown above an example section, ""
+// for plain code
+func sectionHeading(section proverbs.ExampleSection) string {
+	if section.Kind == proverbs.SectionCode {
+		return section.Label
+	}
+	heading := strings.Title(string(section.Kind))
+	if section.Label != "" {
+		heading += ": " + section.Label
+	}
+	return heading
+}
+
+// bookTags renders the tag index, a section per tag listing its proverbs
+func bookTags(all []proverbs.Proverb) string {
+	byTag := make(map[string][]proverbs.Proverb)
+	for _, p := range all {
+		for _, tag := range p.Tags {
+			byTag[tag] = append(byTag[tag], p)
+		}
+	}
+	tags := slices.SortedFunc(maps.Keys(byTag), func(a, b string) int {
+		return cmp.Or(cmp.Compare(strings.ToLower(a), strings.ToLower(b)), cmp.Compare(a, b))
+	})
+
+	var b strings.Builder
+	b.WriteString("# Tags\n\n")
+	links := make([]string, len(tags))
+	for i, tag := range tags {
+		links[i] = fmt.Sprintf("[%s](#%s)", escapeLinkText(tag), headingID(tag))
+	}
+	fmt.Fprintf(&b, "%s\n", strings.Join(links, " · "))
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "\n## %s\n\n", tag)
+		for _, p := range byTag[tag] {
+			fmt.Fprintf(&b, "- [%s](%s)\n", escapeLinkText(p.Title), chapterPath(p))
+		}
+	}
+	return b.String()
+}
+
+// nonIDChars matches the characters mdBook and GitBook drop from headings
+// when making their anchors
+var nonIDChars = regexp.MustCompile(`[^\p{L}\p{N}_ -]`)
+
+// headingID returns the anchor of a Markdown heading
+func headingID(heading string) string {
+	id := nonIDChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(heading)), "")
+	return strings.ReplaceAll(id, " ", "-")
+}
+
+// escapeLinkText escapes the characters that would end a link's text
+func escapeLinkText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`).Replace(s)
+}
+
+// backtickRun matches runs of backticks, which a code fence must outlast
+var backtickRun = regexp.MustCompile("`{3,}")
+
+// fencedCode wraps code in a fenced block longer than any run of backticks
+// inside it
+func fencedCode(lang, code string) string {
+	fence := "```"
+	for _, run := range backtickRun.FindAllString(code, -1) {
+		if len(run) >= len(fence) {
+			fence = strings.Repeat("`", len(run)+1)
+		}
+	}
+	return fence + lang + "\n" + code + "\n" + fence
+}
@@ -0,0 +1,531 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"hash/crc32"
+	"html/template"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+// ebookCSS styles the EPUB chapters and is inlined into the single-file
+// HTML book
+const ebookCSS = `body { font-family: Georgia, serif; line-height: 1.5; margin: 0 auto; max-width: 46em; padding: 0 1em; }
+h1, h2, h3, h4 { font-family: Helvetica, Arial, sans-serif; line-height: 1.2; }
+blockquote { border-left: 4px solid #007acc; margin: 1em 0; padding: 0.2em 1em; font-size: 1.1em; font-style: italic; }
+.author { font-style: normal; font-size: 0.9em; color: #555; }
+.meta, .tags { font-size: 0.85em; color: #555; }
+.section-heading { font-family: Helvetica, Arial, sans-serif; font-weight: bold; margin-bottom: 0.3em; }
+.section-bad { color: #b31d28; }
+.section-good { color: #22863a; }
+pre { background: #f6f8fa; border: 1px solid #e1e4e8; border-radius: 4px; padding: 0.6em; font-size: 0.8em; line-height: 1.4; white-space: pre-wrap; overflow-wrap: anywhere; }
+nav ol { list-style: none; padding-left: 1.2em; }
+a { color: #005a99; }
+.proverb { margin: 2.5em 0; }
+`
+
+// ebookTemplates render the EPUB chapters and the single-file HTML book.
+// The EPUB pages are XHTML, so every element is closed.
+var ebookTemplates = template.Must(template.New("ebook").Parse(`
+{{define "proverb-body"}}<p class="meta">{{.SourceTitle}} · {{.CategoryTitle}}</p>
+<blockquote><p>{{.Text}}</p>{{with .Author}}<p class="author">— {{.}}</p>{{end}}</blockquote>
+{{range .Paragraphs}}<p>{{.}}</p>
+{{end}}{{if .Sections}}<p class="section-heading">Example{{with .GoVersion}} (requires Go {{.}} or later){{end}}</p>
+{{range .Sections}}{{if .Heading}}<p class="section-heading section-{{.Kind}}">{{.Heading}}</p>
+{{end}}<pre><code>{{.Code}}</code></pre>
+{{end}}{{with .Output}}<p class="section-heading">Output</p>
+<pre>{{.}}</pre>
+{{end}}{{end}}{{with .Tags}}<p class="tags">Tags: {{range $i, $tag := .}}{{if $i}}, {{end}}{{$tag}}{{end}}</p>
+{{end}}{{end}}
+
+{{define "xhtml-head"}}<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" xml:lang="en" lang="en">
+<head>
+<meta charset="utf-8"/>
+<title>{{.}}</title>
+<link rel="stylesheet" type="text/css" href="style.css"/>
+</head>
+{{end}}
+
+{{define "epub-title"}}{{template "xhtml-head" .Title}}<body epub:type="frontmatter">
+<section epub:type="titlepage">
+<h1>{{.Title}}</h1>
+{{range .Intro}}<p>{{.}}</p>
+{{end}}</section>
+</body>
+</html>
+{{end}}
+
+{{define "epub-category"}}{{template "xhtml-head" .Title}}<body>
+<section epub:type="chapter">
+<h1>{{.Title}}</h1>
+<p class="meta">{{.PartTitle}}</p>
+<ol>
+{{range .Proverbs}}<li><a href="{{.File}}">{{.Title}}</a></li>
+{{end}}</ol>
+</section>
+</body>
+</html>
+{{end}}
+
+{{define "epub-proverb"}}{{template "xhtml-head" .Title}}<body>
+<section epub:type="chapter" id="{{.ID}}">
+<h2>{{.Title}}</h2>
+{{template "proverb-body" .}}</section>
+</body>
+</html>
+{{end}}
+
+{{define "html"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>{{.Title}}</title>
+<style>
+{{.CSS}}</style>
+</head>
+<body>
+<header>
+<h1>{{.Title}}</h1>
+{{range .Intro}}<p>{{.}}</p>
+{{end}}</header>
+<nav>
+<h2>Contents</h2>
+<ol>
+{{range .Parts}}<li><a href="#{{.ID}}">{{.Title}}</a>
+<ol>
+{{range .Categories}}<li><a href="#{{.ID}}">{{.Title}}</a>
+<ol>
+{{range .Proverbs}}<li><a href="#{{.ID}}">{{.Title}}</a></li>
+{{end}}</ol>
+</li>
+{{end}}</ol>
+</li>
+{{end}}</ol>
+</nav>
+{{range .Parts}}<section id="{{.ID}}">
+<h2>{{.Title}}</h2>
+{{range .Categories}}<section id="{{.ID}}">
+<h3>{{.Title}}</h3>
+{{range .Proverbs}}<article class="proverb" id="{{.ID}}">
+<h4>{{.Title}}</h4>
+{{template "proverb-body" .}}</article>
+{{end}}</section>
+{{end}}</section>
+{{end}}</body>
+</html>
+{{end}}
+`))
+
+// ebook is the collection laid out as a book: a part per source holding a
+// section per category
+type ebook struct {
+	Title    string
+	Intro    []string
+	CSS      template.CSS
+	Parts    []ebookPart
+	Modified time.Time
+}
+
+// ebookPart is the proverbs of one source
+type ebookPart struct {
+	ID         string
+	Title      string
+	Categories []ebookCategory
+}
+
+// ebookCategory is a category section of a part
+type ebookCategory struct {
+	ID        string
+	Title     string
+	PartTitle string
+	File      string
+	Proverbs  []ebookProverb
+}
+
+// ebookProverb is a proverb as a chapter
+type ebookProverb struct {
+	proverbs.Proverb
+	File          string
+	SourceTitle   string
+	CategoryTitle string
+	Paragraphs    []string
+	Sections      []ebookSection
+	GoVersion     string
+	Output        string
+}
+
+// ebookSection is an example section with its heading
+type ebookSection struct {
+	Kind    proverbs.SectionKind
+	Heading string
+	Code    string
+}
+
+// newEbook lays out the proverbs in store as a book, modified as of
+// modifiedTime
+func newEbook(store proverbs.Store) (*ebook, error) {
+	all, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	modified, err := modifiedTime(all)
+	if err != nil {
+		return nil, err
+	}
+	stats := proverbs.ComputeStats(all)
+	book := &ebook{
+		Title: bookTitle,
+		Intro: []string{
+			"A collection of Go programming wisdom: the official Go proverbs from Rob Pike's talk at Gopherfest SV 2015, and proverbs contributed by the community.",
+			fmt.Sprintf("This book holds %d proverbs, %d official and %d from the community, each with an explanation and an example.", stats.Total, stats.Official, stats.Community),
+		},
+		CSS:      template.CSS(ebookCSS),
+		Modified: modified,
+	}
+
+	for _, group := range chapterGroups(all) {
+		if len(book.Parts) == 0 || book.Parts[len(book.Parts)-1].ID != string(group.Source) {
+			book.Parts = append(book.Parts, ebookPart{ID: string(group.Source), Title: sourceTitle(group.Source)})
+		}
+		part := &book.Parts[len(book.Parts)-1]
+		id := string(group.Source) + "-" + string(group.Category)
+		category := ebookCategory{
+			ID:        id,
+			Title:     categoryTitle(group.Category),
+			PartTitle: part.Title,
+			File:      id + ".xhtml",
+		}
+		for _, p := range group.Proverbs {
+			category.Proverbs = append(category.Proverbs, newEbookProverb(p))
+		}
+		part.Categories = append(part.Categories, category)
+	}
+	return book, nil
+}
+
+// undatedModified dates a book whose proverbs are all undated: the day the
+// Go proverbs were first given
+var undatedModified = time.Date(2015, 11, 18, 0, 0, 0, 0, time.UTC)
+
+// modifiedTime returns when a book of the proverbs was last modified, so
+// exporting the same proverbs twice gives the same file: the time
+// SOURCE_DATE_EPOCH holds in seconds when it is set, as for reproducible
+// builds, and otherwise the newest creation time of the dated proverbs, or
+// undatedModified when none is dated
+func modifiedTime(all []proverbs.Proverb) (time.Time, error) {
+	if epoch := os.Getenv("SOURCE_DATE_EPOCH"); epoch != "" {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid SOURCE_DATE_EPOCH %q", epoch)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	var newest time.Time
+	for _, p := range all {
+		if p.CreatedAt.After(newest) {
+			newest = p.CreatedAt
+		}
+	}
+	if newest.IsZero() {
+		return undatedModified, nil
+	}
+	return newest.UTC().Truncate(time.Second), nil
+}
+
+// newEbookProverb prepares a proverb's chapter
+func newEbookProverb(p proverbs.Proverb) ebookProverb {
+	chapter := ebookProverb{
+		Proverb:       p,
+		File:          p.ID + ".xhtml",
+		SourceTitle:   sourceTitle(p.Source),
+		CategoryTitle: categoryTitle(p.Category),
+		GoVersion:     p.MinGoVersion(),
+	}
+	for _, paragraph := range strings.Split(p.Explanation, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			chapter.Paragraphs = append(chapter.Paragraphs, paragraph)
+		}
+	}
+	for _, section := range exampleSections(p) {
+		chapter.Sections = append(chapter.Sections, ebookSection{
+			Kind:    section.Kind,
+			Heading: sectionHeading(section),
+			Code:    section.Code,
+		})
+	}
+	if len(chapter.Sections) > 0 {
+		output, _, _ := proverbs.ExpectedOutput(p.Example)
+		chapter.Output = strings.TrimRight(output, "\n")
+	}
+	return chapter
+}
+
+// HTML writes the proverbs in store to w as a single self-contained HTML
+// page with a table of contents and inlined CSS
+func HTML(store proverbs.Store, w io.Writer) error {
+	book, err := newEbook(store)
+	if err != nil {
+		return err
+	}
+	return ebookTemplates.ExecuteTemplate(w, "html", book)
+}
+
+// EPUB writes the proverbs in store to w as an EPUB 3 book. Every category
+// is a section opening with a list of its proverbs, followed by a chapter
+// per proverb, and the navigation document nests them under their source.
+func EPUB(store proverbs.Store, w io.Writer) error {
+	book, err := newEbook(store)
+	if err != nil {
+		return err
+	}
+
+	// The package document lists every file and the reading order
+	opf := opfPackage{
+		Xmlns:    "http://www.idpf.org/2007/opf",
+		Version:  "3.0",
+		UniqueID: "book-id",
+		Lang:     "en",
+		Metadata: opfMetadata{
+			XmlnsDC:    "http://purl.org/dc/elements/1.1/",
+			Identifier: opfIdentifier{ID: "book-id", Value: book.identifier()},
+			Title:      book.Title,
+			Language:   "en",
+			Creator:    "Go Proverbs contributors",
+			Meta:       []opfMeta{{Property: "dcterms:modified", Value: book.Modified.Format(time.RFC3339)}},
+		},
+		Manifest: []opfItem{
+			{ID: "nav", Href: "nav.xhtml", MediaType: "application/xhtml+xml", Properties: "nav"},
+			{ID: "style", Href: "style.css", MediaType: "text/css"},
+			{ID: "title", Href: "title.xhtml", MediaType: "application/xhtml+xml"},
+		},
+		Spine: []opfItemRef{{IDRef: "title"}},
+	}
+	pages := map[string]any{"title.xhtml": book}
+	nav := navList{}
+	for _, part := range book.Parts {
+		partItem := navItem{Span: part.Title, Children: &navList{}}
+		for _, category := range part.Categories {
+			opf.add("c-"+category.ID, category.File)
+			pages[category.File] = category
+			categoryItem := navItem{Link: &navLink{Href: category.File, Text: category.Title}, Children: &navList{}}
+			for _, p := range category.Proverbs {
+				opf.add("p-"+p.ID, p.File)
+				pages[p.File] = p
+				categoryItem.Children.Items = append(categoryItem.Children.Items, navItem{Link: &navLink{Href: p.File, Text: p.Title}})
+			}
+			partItem.Children.Items = append(partItem.Children.Items, categoryItem)
+		}
+		nav.Items = append(nav.Items, partItem)
+	}
+
+	zw := zip.NewWriter(w)
+
+	// The mimetype comes first, stored uncompressed and without extra
+	// fields, so readers can identify the file by its leading bytes
+	mimetype := []byte("application/epub+zip")
+	mw, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "mimetype",
+		Method:             zip.Store,
+		CRC32:              crc32.ChecksumIEEE(mimetype),
+		CompressedSize64:   uint64(len(mimetype)),
+		UncompressedSize64: uint64(len(mimetype)),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err := mw.Write(mimetype); err != nil {
+		return err
+	}
+
+	add := func(name string, data []byte) error {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: book.Modified})
+		if err != nil {
+			return err
+		}
+		_, err = fw.Write(data)
+		return err
+	}
+	addXML := func(name string, v any) error {
+		data, err := xml.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return add(name, append([]byte(xml.Header), append(data, '\n')...))
+	}
+
+	container := epubContainer{
+		Xmlns:     "urn:oasis:names:tc:opendocument:xmlns:container",
+		Version:   "1.0",
+		Rootfiles: []epubRootfile{{FullPath: "OEBPS/content.opf", MediaType: "application/oebps-package+xml"}},
+	}
+	if err := addXML("META-INF/container.xml", container); err != nil {
+		return err
+	}
+	if err := addXML("OEBPS/content.opf", opf); err != nil {
+		return err
+	}
+	navDoc := navHTML{
+		Xmlns:     "http://www.w3.org/1999/xhtml",
+		XmlnsEpub: "http://www.idpf.org/2007/ops",
+		Lang:      "en",
+		Title:     book.Title,
+		Nav:       navElem{Type: "toc", ID: "toc", Heading: "Contents", List: nav},
+	}
+	if err := addXML("OEBPS/nav.xhtml", navDoc); err != nil {
+		return err
+	}
+	if err := add("OEBPS/style.css", []byte(ebookCSS)); err != nil {
+		return err
+	}
+
+	for _, item := range opf.Manifest {
+		data, ok := pages[item.Href]
+		if !ok {
+			continue
+		}
+		var page bytes.Buffer
+		page.WriteString(xml.Header + "<!DOCTYPE html>\n")
+		if err := ebookTemplates.ExecuteTemplate(&page, ebookPage(data), data); err != nil {
+			return err
+		}
+		if err := add("OEBPS/"+item.Href, page.Bytes()); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ebookPage returns the template rendering a page of an EPUB
+func ebookPage(data any) string {
+	switch data.(type) {
+	case ebookCategory:
+		return "epub-category"
+	case ebookProverb:
+		return "epub-proverb"
+	}
+	return "epub-title"
+}
+
+// identifier returns a UUID URN derived from the book's chapters, so
+// readers recognize a new export of the same collection as the same book
+func (book *ebook) identifier() string {
+	h := sha1.New()
+	for _, part := range book.Parts {
+		for _, category := range part.Categories {
+			for _, p := range category.Proverbs {
+				fmt.Fprintln(h, p.ID)
+			}
+		}
+	}
+	sum := h.Sum(nil)
+	sum[6] = sum[6]&0x0f | 0x50 // version 5
+	sum[8] = sum[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// epubContainer is META-INF/container.xml, pointing readers at the
+// package document
+type epubContainer struct {
+	XMLName   xml.Name       `xml:"container"`
+	Xmlns     string         `xml:"xmlns,attr"`
+	Version   string         `xml:"version,attr"`
+	Rootfiles []epubRootfile `xml:"rootfiles>rootfile"`
+}
+
+type epubRootfile struct {
+	FullPath  string `xml:"full-path,attr"`
+	MediaType string `xml:"media-type,attr"`
+}
+
+// opfPackage is the package document
+type opfPackage struct {
+	XMLName  xml.Name     `xml:"package"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Version  string       `xml:"version,attr"`
+	UniqueID string       `xml:"unique-identifier,attr"`
+	Lang     string       `xml:"xml:lang,attr"`
+	Metadata opfMetadata  `xml:"metadata"`
+	Manifest []opfItem    `xml:"manifest>item"`
+	Spine    []opfItemRef `xml:"spine>itemref"`
+}
+
+// add lists an XHTML page in the manifest and the spine
+func (opf *opfPackage) add(id, href string) {
+	opf.Manifest = append(opf.Manifest, opfItem{ID: id, Href: href, MediaType: "application/xhtml+xml"})
+	opf.Spine = append(opf.Spine, opfItemRef{IDRef: id})
+}
+
+type opfMetadata struct {
+	XmlnsDC    string        `xml:"xmlns:dc,attr"`
+	Identifier opfIdentifier `xml:"dc:identifier"`
+	Title      string        `xml:"dc:title"`
+	Language   string        `xml:"dc:language"`
+	Creator    string        `xml:"dc:creator"`
+	Meta       []opfMeta     `xml:"meta"`
+}
+
+type opfIdentifier struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opfMeta struct {
+	Property string `xml:"property,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type opfItem struct {
+	ID         string `xml:"id,attr"`
+	Href       string `xml:"href,attr"`
+	MediaType  string `xml:"media-type,attr"`
+	Properties string `xml:"properties,attr,omitempty"`
+}
+
+type opfItemRef struct {
+	IDRef string `xml:"idref,attr"`
+}
+
+// navHTML is the navigation document, an XHTML page holding the table of
+// contents
+type navHTML struct {
+	XMLName   xml.Name `xml:"html"`
+	Xmlns     string   `xml:"xmlns,attr"`
+	XmlnsEpub string   `xml:"xmlns:epub,attr"`
+	Lang      string   `xml:"xml:lang,attr"`
+	Title     string   `xml:"head>title"`
+	Nav       navElem  `xml:"body>nav"`
+}
+
+type navElem struct {
+	Type    string  `xml:"epub:type,attr"`
+	ID      string  `xml:"id,attr"`
+	Heading string  `xml:"h1"`
+	List    navList `xml:"ol"`
+}
+
+type navList struct {
+	Items []navItem `xml:"li"`
+}
+
+// navItem is an entry of the table of contents. Parts have no page of
+// their own, so they are a span rather than a link.
+type navItem struct {
+	Link     *navLink `xml:"a,omitempty"`
+	Span     string   `xml:"span,omitempty"`
+	Children *navList `xml:"ol,omitempty"`
+}
+
+type navLink struct {
+	Href string `xml:"href,attr"`
+	Text string `xml:",chardata"`
+}
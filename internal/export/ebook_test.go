@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+func TestModifiedTime(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		name string
+		all  []proverbs.Proverb
+		want time.Time
+	}{
+		{"none", nil, undatedModified},
+		{"undated", []proverbs.Proverb{{ID: "a"}, {ID: "b"}}, undatedModified},
+		{"newest", []proverbs.Proverb{{CreatedAt: day(2)}, {CreatedAt: day(5)}, {CreatedAt: day(3)}}, day(5)},
+		{"undated ignored", []proverbs.Proverb{{}, {CreatedAt: day(2)}, {}}, day(2)},
+		{"truncated to seconds", []proverbs.Proverb{{CreatedAt: day(2).Add(1500 * time.Millisecond)}}, day(2).Add(time.Second)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := modifiedTime(tt.all)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("modifiedTime = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Setenv("SOURCE_DATE_EPOCH", "1700000000")
+	if got, err := modifiedTime(nil); err != nil || !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("modifiedTime with SOURCE_DATE_EPOCH = %v, %v", got, err)
+	}
+	t.Setenv("SOURCE_DATE_EPOCH", "soon")
+	if _, err := modifiedTime(nil); err == nil {
+		t.Error("modifiedTime with an invalid SOURCE_DATE_EPOCH succeeded")
+	}
+}
+
+// TestEPUBReproducible exports the data files, most of them undated, twice
+// a second apart and checks the books are identical
+func TestEPUBReproducible(t *testing.T) {
+	t.Setenv("SOURCE_DATE_EPOCH", "")
+	var books [2][]byte
+	for i := range books {
+		if i > 0 {
+			time.Sleep(time.Second)
+		}
+		collection, err := proverbs.LoadFromFS(os.DirFS("../proverbs/examples"), "official", "community")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := EPUB(collection, &buf); err != nil {
+			t.Fatal(err)
+		}
+		books[i] = buf.Bytes()
+	}
+	if !bytes.Equal(books[0], books[1]) {
+		t.Error("exporting the same proverbs twice gave different EPUB files")
+	}
+}
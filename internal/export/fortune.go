@@ -0,0 +1,109 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+// FortuneName is the name of the fortune file, so that
+// "fortune go-proverbs" picks from it
+const FortuneName = "go-proverbs"
+
+// fortuneWidth is the width proverbs are wrapped to in the fortune file,
+// which fortune prints as is
+const fortuneWidth = 72
+
+// strfile header constants: the version of the .dat layout and the
+// delimiter between fortunes
+const (
+	strfileVersion = 2
+	fortuneDelim   = '%'
+)
+
+// FormatFortune formats a proverb as a fortune: its text wrapped to width
+// columns, followed by its author
+func FormatFortune(p proverbs.Proverb, width int) string {
+	var b strings.Builder
+	b.WriteString(Wrap(p.Text, width))
+	if p.Author != "" {
+		b.WriteString("\t\t-- " + p.Author + "\n")
+	}
+	return b.String()
+}
+
+// Wrap breaks text into lines of at most width columns at spaces, ending
+// every line with a newline. Words longer than width get a line of their
+// own.
+func Wrap(text string, width int) string {
+	var b strings.Builder
+	column := 0
+	for _, word := range strings.Fields(text) {
+		n := len([]rune(word))
+		switch {
+		case column == 0:
+		case column+1+n > width:
+			b.WriteByte('\n')
+			column = 0
+		default:
+			b.WriteByte(' ')
+			column++
+		}
+		b.WriteString(word)
+		column += n
+	}
+	if column > 0 {
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Fortune writes the proverbs in store to dir as a fortune file named
+// FortuneName, each proverb followed by a "%" line, along with the
+// FortuneName.dat index strfile would build for it
+func Fortune(store proverbs.Store, dir string) error {
+	all, err := store.List()
+	if err != nil {
+		return err
+	}
+	var text bytes.Buffer
+	offsets := []uint32{0}
+	for _, p := range all {
+		text.WriteString(FormatFortune(p, fortuneWidth))
+		text.WriteString(string(fortuneDelim) + "\n")
+		offsets = append(offsets, uint32(text.Len()))
+	}
+
+	if err := writeOut(filepath.Join(dir, FortuneName), text.Bytes()); err != nil {
+		return err
+	}
+	return writeOut(filepath.Join(dir, FortuneName+".dat"), strfileIndex(offsets))
+}
+
+// strfileIndex encodes the index of a fortune file in strfile's layout:
+// a header of big-endian 32-bit version, count, longest and shortest
+// length, flags and the delimiter padded to four bytes, followed by the
+// offset of every fortune and the offset of the end of the file. Lengths
+// count a fortune's text without its delimiter line.
+func strfileIndex(offsets []uint32) []byte {
+	count := uint32(len(offsets) - 1)
+	var longest, shortest uint32
+	for i := range count {
+		n := offsets[i+1] - offsets[i] - 2 // the "%\n" line
+		longest = max(longest, n)
+		if i == 0 || n < shortest {
+			shortest = n
+		}
+	}
+
+	var dat bytes.Buffer
+	for _, v := range []uint32{strfileVersion, count, longest, shortest, 0} {
+		binary.Write(&dat, binary.BigEndian, v)
+	}
+	dat.Write([]byte{fortuneDelim, 0, 0, 0})
+	binary.Write(&dat, binary.BigEndian, offsets)
+	return dat.Bytes()
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+// strfileHeader is the header strfileIndex writes
+type strfileHeader struct {
+	Version, Count, Longest, Shortest, Flags uint32
+	Delim                                    [4]byte
+}
+
+func TestStrfileIndex(t *testing.T) {
+	tests := []struct {
+		name     string
+		offsets  []uint32
+		count    uint32
+		longest  uint32
+		shortest uint32
+	}{
+		{"empty", []uint32{0}, 0, 0, 0},
+		{"one", []uint32{0, 10}, 1, 8, 8},
+		{"several", []uint32{0, 10, 14, 40}, 3, 24, 2},
+		{"shortest first", []uint32{0, 3, 30}, 2, 25, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dat := strfileIndex(tt.offsets)
+			var header strfileHeader
+			r := bytes.NewReader(dat)
+			if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+				t.Fatal(err)
+			}
+			want := strfileHeader{
+				Version:  strfileVersion,
+				Count:    tt.count,
+				Longest:  tt.longest,
+				Shortest: tt.shortest,
+				Delim:    [4]byte{'%'},
+			}
+			if header != want {
+				t.Errorf("header = %+v, want %+v", header, want)
+			}
+
+			offsets := make([]uint32, r.Len()/4)
+			if err := binary.Read(r, binary.BigEndian, offsets); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(offsets, tt.offsets) {
+				t.Errorf("offsets = %v, want %v", offsets, tt.offsets)
+			}
+			if len(dat) != 24+4*len(tt.offsets) {
+				t.Errorf("index is %d bytes, want %d", len(dat), 24+4*len(tt.offsets))
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"", 10, ""},
+		{"short", 10, "short\n"},
+		{"Don't panic.", 12, "Don't panic.\n"},
+		{"Don't panic.", 11, "Don't\npanic.\n"},
+		{"a  b\n c", 10, "a b c\n"},
+		{"Errors are values", 6, "Errors\nare\nvalues\n"},
+		{"tiny extraordinarily long", 5, "tiny\nextraordinarily\nlong\n"},
+		{"héllo wörld", 11, "héllo wörld\n"},
+	}
+	for _, tt := range tests {
+		if got := Wrap(tt.text, tt.width); got != tt.want {
+			t.Errorf("Wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+// Package export writes the proverb collection in formats that are read
+// without the server: a static site, books and fortune files.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+// rootLink matches the start of an attribute linking to a root-relative URL,
+// leaving protocol-relative URLs alone
+var rootLink = regexp.MustCompile(`\b(href|src|action)="/([^/])`)
+
+// randomPage picks a proverb in the browser, standing in for the /random
+// redirect of the server
+var randomPage = template.Must(template.New("random").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <title>Random Proverb - Go Proverbs</title>
+    <script>
+        var ids = {{.IDs}};
+        location.replace({{.Base}} + "/proverbs/" + ids[Math.floor(Math.random() * ids.length)]);
+    </script>
+</head>
+<body>
+    <noscript><a href="{{.Base}}/">Go Proverbs</a></noscript>
+</body>
+</html>
+`))
+
+// Site renders every page and JSON API response handler serves for the
+// proverbs in store into dir, along with the files of static. Pages are
+// written as <route>/index.html and API responses as <route>.json. Links
+// are rewritten to start with base, the URL path the site is served from.
+//
+// The handler should render pages for a static copy, as a web.Handler
+// does after RenderStatic, since searching, sorting and running examples
+// need the server.
+func Site(handler http.Handler, store proverbs.Store, static fs.FS, dir, base string) error {
+	base, err := cleanBase(base)
+	if err != nil {
+		return err
+	}
+	all, err := store.List()
+	if err != nil {
+		return err
+	}
+	stats := proverbs.ComputeStats(all)
+
+	pages := []string{"/", "/categories", "/tags"}
+	apis := []string{
+		"/api/v1/proverbs?limit=" + strconv.Itoa(len(all)),
+		"/api/v1/proverbs/stats",
+		"/api/v1/validation",
+	}
+	ids := make([]string, len(all))
+	for i, p := range all {
+		ids[i] = p.ID
+		pages = append(pages, "/proverbs/"+url.PathEscape(p.ID))
+	}
+	for _, category := range slices.Sorted(maps.Keys(stats.Categories)) {
+		pages = append(pages, "/categories/"+url.PathEscape(string(category)))
+		apis = append(apis, "/api/v1/proverbs/categories/"+url.PathEscape(string(category)))
+	}
+	for _, tag := range slices.Sorted(maps.Keys(stats.Tags)) {
+		pages = append(pages, "/tags/"+url.PathEscape(tag))
+		apis = append(apis, "/api/v1/proverbs/tags/"+url.PathEscape(tag))
+	}
+	for _, source := range []proverbs.Source{proverbs.SourceOfficial, proverbs.SourceCommunity} {
+		pages = append(pages, "/sources/"+url.PathEscape(string(source)))
+		apis = append(apis, "/api/v1/proverbs/sources/"+url.PathEscape(string(source)))
+	}
+
+	for _, page := range pages {
+		body, err := get(handler, page)
+		if err != nil {
+			return err
+		}
+		if base != "" {
+			body = rootLink.ReplaceAll(body, []byte(`$1="`+base+`/$2`))
+		}
+		if err := writeFile(dir, page, "/index.html", body); err != nil {
+			return err
+		}
+	}
+	for _, api := range apis {
+		body, err := get(handler, api)
+		if err != nil {
+			return err
+		}
+		route, _, _ := strings.Cut(api, "?")
+		if err := writeFile(dir, route, ".json", body); err != nil {
+			return err
+		}
+	}
+
+	var random bytes.Buffer
+	if err := randomPage.Execute(&random, map[string]any{"IDs": ids, "Base": base}); err != nil {
+		return err
+	}
+	if err := writeFile(dir, "/random", "/index.html", random.Bytes()); err != nil {
+		return err
+	}
+	return copyStatic(static, filepath.Join(dir, "static"))
+}
+
+// cleanBase normalizes the URL path a site is served from to start with a
+// slash and not end with one, so the root becomes ""
+func cleanBase(base string) (string, error) {
+	if strings.ContainsAny(base, "\"'<>?# \t\n") {
+		return "", fmt.Errorf("invalid base path %q", base)
+	}
+	base = path.Clean("/" + base)
+	if base == "/" {
+		return "", nil
+	}
+	return base, nil
+}
+
+// get returns the body of a successful GET of target from handler
+func get(handler http.Handler, target string) ([]byte, error) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	if rec.Code != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %d %s", target, rec.Code, strings.TrimSpace(rec.Body.String()))
+	}
+	return rec.Body.Bytes(), nil
+}
+
+// writeFile writes body to the file for route in dir, the unescaped route
+// followed by suffix
+func writeFile(dir, route, suffix string, body []byte) error {
+	name, err := url.PathUnescape(strings.TrimSuffix(route, "/") + suffix)
+	if err != nil {
+		return err
+	}
+	name = strings.TrimPrefix(name, "/")
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("route %s leaves the output directory", route)
+	}
+	return writeOut(filepath.Join(dir, filepath.FromSlash(name)), body)
+}
+
+// copyStatic copies the files of static into dir
+func copyStatic(static fs.FS, dir string) error {
+	return fs.WalkDir(static, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(static, name)
+		if err != nil {
+			return err
+		}
+		return writeOut(filepath.Join(dir, filepath.FromSlash(name)), data)
+	})
+}
+
+// writeOut writes data to file, creating its directory
+func writeOut(file string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o644)
+}
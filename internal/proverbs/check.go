@@ -0,0 +1,380 @@
+package proverbs
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	gotoken "go/token"
+	"go/types"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ExampleExt is the extension of example files. Each example sits next to
+// the data file with the same ID, e.g. official-001.gotmpl.
+const ExampleExt = ".gotmpl"
+
+// examplePackage names the synthetic package fragments are wrapped in
+const examplePackage = "example"
+
+// stdPackages maps the names fragments use for standard library packages
+// they do not import to their import paths
+var stdPackages = map[string]string{
+	"atomic":   "sync/atomic",
+	"base64":   "encoding/base64",
+	"binary":   "encoding/binary",
+	"bufio":    "bufio",
+	"bytes":    "bytes",
+	"cmp":      "cmp",
+	"context":  "context",
+	"debug":    "runtime/debug",
+	"errors":   "errors",
+	"exec":     "os/exec",
+	"filepath": "path/filepath",
+	"flag":     "flag",
+	"fmt":      "fmt",
+	"heap":     "container/heap",
+	"hex":      "encoding/hex",
+	"http":     "net/http",
+	"httptest": "net/http/httptest",
+	"io":       "io",
+	"ioutil":   "io/ioutil",
+	"json":     "encoding/json",
+	"list":     "container/list",
+	"log":      "log",
+	"maps":     "maps",
+	"math":     "math",
+	"net":      "net",
+	"os":       "os",
+	"path":     "path",
+	"pprof":    "runtime/pprof",
+	"quick":    "testing/quick",
+	"rand":     "math/rand",
+	"reflect":  "reflect",
+	"regexp":   "regexp",
+	"runtime":  "runtime",
+	"sha256":   "crypto/sha256",
+	"signal":   "os/signal",
+	"slices":   "slices",
+	"slog":     "log/slog",
+	"sort":     "sort",
+	"sql":      "database/sql",
+	"strconv":  "strconv",
+	"strings":  "strings",
+	"sync":     "sync",
+	"syscall":  "syscall",
+	"template": "text/template",
+	"testing":  "testing",
+	"time":     "time",
+	"trace":    "runtime/trace",
+	"unicode":  "unicode",
+	"unsafe":   "unsafe",
+	"url":      "net/url",
+	"utf8":     "unicode/utf8",
+}
+
+// stdImporter loads standard library packages from the Go toolchain's
+// export data. It is shared so each package is only loaded once, and nil
+// when no toolchain is available, in which case examples are only parsed.
+var stdImporter = sync.OnceValue(func() types.Importer {
+	imp := importer.Default()
+	if _, err := imp.Import("fmt"); err != nil {
+		return nil
+	}
+	return imp
+})
+
+// checkMu serializes type checks, as the shared importer is not safe for
+// concurrent use
+var checkMu sync.Mutex
+
+// ExampleFile returns the path of a proverb's example relative to the data
+// directory, e.g. official/official-001.gotmpl
+func ExampleFile(proverb Proverb) string {
+	return path.Join(string(proverb.Source), proverb.ID+ExampleExt)
+}
+
+// CheckExample validates an example's front matter, then parses and
+// type-checks it against the standard library for the Go version it
+// declares, reporting every failure with its position in file. Fragments
+// are laid out as described on layoutExample. An empty example is not
+// checked.
+func CheckExample(id, file, src string) []ValidationError {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+
+	meta, errs := checkFrontMatter(id, file, src)
+	layout, layoutErrs := layoutExample(id, file, src)
+	if layoutErrs != nil {
+		return append(errs, layoutErrs...)
+	}
+	layout.imports, layout.goVersion = meta.Imports, meta.GoVersion
+	return append(errs, checkSource(id, layout)...)
+}
+
+// checkFrontMatter parses an example's front matter, reporting problems
+// with it and output declared both there and in a trailer
+func checkFrontMatter(id, file, src string) (ExampleMeta, []ValidationError) {
+	meta, _, err := ParseFrontMatter(src)
+	var errs []ValidationError
+	var dataErrs DataErrors
+	if errors.As(err, &dataErrs) {
+		for _, de := range dataErrs {
+			errs = append(errs, ValidationError{
+				ProverbID: id,
+				Field:     "Example",
+				File:      file,
+				Line:      de.Line,
+				Column:    1,
+				Message:   de.Message,
+			})
+		}
+	}
+	lines := strings.Split(strings.TrimRight(src, " \t\r\n"), "\n")
+	if i := outputStart(lines); i >= 0 && meta.Output != "" {
+		errs = append(errs, ValidationError{
+			ProverbID: id,
+			Field:     "Example",
+			File:      file,
+			Line:      i + 1,
+			Column:    1,
+			Message:   "output declared in both the front matter and an \"// Output:\" trailer",
+		})
+	}
+	return meta, errs
+}
+
+// fragment is a run of example lines starting at line, placed either at
+// the top level or in the body of a function
+type fragment struct {
+	line int
+	src  string
+	body bool
+}
+
+// exampleLayout is an example assembled into a single Go file
+type exampleLayout struct {
+	file      string
+	wrap      bool // add a package clause and missing imports
+	fragments []fragment
+	imports   []string // declared in the front matter
+	goVersion string   // declared in the front matter
+}
+
+// hasBody reports whether any fragment is placed in a function body
+func (l exampleLayout) hasBody() bool {
+	return slices.ContainsFunc(l.fragments, func(f fragment) bool { return f.body })
+}
+
+// langVersion returns the language version to check the layout against,
+// e.g. "go1.22", or "" for the latest
+func (l exampleLayout) langVersion() string {
+	if l.goVersion == "" {
+		return ""
+	}
+	return "go" + l.goVersion
+}
+
+// source builds the file, naming the package pkg and the function holding
+// statements bodyFunc. //line directives keep positions relative to the
+// example.
+func (l exampleLayout) source(pkg, bodyFunc string, imports []string) string {
+	var b strings.Builder
+	if l.wrap {
+		fmt.Fprintf(&b, "package %s\n", pkg)
+		for _, imp := range imports {
+			fmt.Fprintf(&b, "import %s\n", strconv.Quote(imp))
+		}
+	}
+	for _, f := range l.fragments {
+		if f.body {
+			fmt.Fprintf(&b, "func %s() {\n", bodyFunc)
+		}
+		fmt.Fprintf(&b, "//line %s:%d:1\n", l.file, f.line)
+		b.WriteString(f.src)
+		if f.body {
+			b.WriteString("\n}\n")
+		} else if !strings.HasSuffix(f.src, "\n") {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// parse builds and parses the file, importing the standard library
+// packages a wrapped fragment uses. It returns the parsed file and its
+// source.
+func (l exampleLayout) parse(fset *gotoken.FileSet, pkg, bodyFunc string) (*ast.File, string, error) {
+	// The file is unnamed so the //line file names are not resolved
+	// relative to it
+	src := l.source(pkg, bodyFunc, nil)
+	f, err := parser.ParseFile(fset, "", src, parser.AllErrors)
+	if err != nil || !l.wrap {
+		return f, src, err
+	}
+	if imports := missingImports(f, l.imports); len(imports) > 0 {
+		src = l.source(pkg, bodyFunc, imports)
+		f, err = parser.ParseFile(fset, "", src, parser.AllErrors)
+	}
+	return f, src, err
+}
+
+// layoutExample finds how to assemble an example into a file. Examples
+// with a package clause are used as they are. Others are fragments, wrapped
+// in a synthetic package first as top-level declarations, then as the body
+// of a function, and finally as declarations and statements split where
+// one of those stops parsing. When no layout parses, it returns the syntax
+// errors of the first.
+func layoutExample(id, file, src string) (exampleLayout, []ValidationError) {
+	if hasPackageClause(src) {
+		layout := exampleLayout{file: file, fragments: []fragment{{line: 1, src: src}}}
+		return layout, parseErrors(id, layout)
+	}
+
+	decls := exampleLayout{file: file, wrap: true, fragments: []fragment{{line: 1, src: src}}}
+	declErrs := parseErrors(id, decls)
+	if declErrs == nil {
+		return decls, nil
+	}
+	body := exampleLayout{file: file, wrap: true, fragments: []fragment{{line: 1, src: src, body: true}}}
+	bodyErrs := parseErrors(id, body)
+	if bodyErrs == nil {
+		return body, nil
+	}
+
+	lines := strings.SplitAfter(src, "\n")
+	for _, split := range []struct {
+		line      int
+		bodyFirst bool
+	}{{declErrs[0].Line, false}, {bodyErrs[0].Line, true}} {
+		if split.line <= 1 || split.line > len(lines) {
+			continue
+		}
+		mixed := exampleLayout{file: file, wrap: true, fragments: []fragment{
+			{line: 1, src: strings.Join(lines[:split.line-1], ""), body: split.bodyFirst},
+			{line: split.line, src: strings.Join(lines[split.line-1:], ""), body: !split.bodyFirst},
+		}}
+		if parseErrors(id, mixed) == nil {
+			return mixed, nil
+		}
+	}
+	return decls, declErrs
+}
+
+// hasPackageClause reports whether src starts with a package clause
+func hasPackageClause(src string) bool {
+	_, err := parser.ParseFile(gotoken.NewFileSet(), "", src, parser.PackageClauseOnly)
+	return err == nil
+}
+
+// parseErrors returns the syntax errors of the layout, or nil if it parses
+func parseErrors(id string, layout exampleLayout) []ValidationError {
+	if _, _, err := layout.parse(gotoken.NewFileSet(), examplePackage, "_"); err != nil {
+		return syntaxErrors(id, err)
+	}
+	return nil
+}
+
+// checkSource type-checks a layout that parses
+func checkSource(id string, layout exampleLayout) []ValidationError {
+	fset := gotoken.NewFileSet()
+	f, _, err := layout.parse(fset, examplePackage, "_")
+	if err != nil {
+		return syntaxErrors(id, err)
+	}
+
+	imp := stdImporter()
+	if imp == nil {
+		return nil
+	}
+
+	inBody := layout.hasBody()
+	var errs []ValidationError
+	conf := types.Config{
+		Importer:  imp,
+		GoVersion: layout.langVersion(),
+		Error: func(err error) {
+			var terr types.Error
+			if !errors.As(err, &terr) {
+				errs = append(errs, ValidationError{ProverbID: id, Field: "Example", File: layout.file, Message: err.Error()})
+				return
+			}
+			// Continuation lines such as "other declaration of x" add
+			// nothing on their own, and unused variables and imports in
+			// statements are usually an artifact of cutting them out of a
+			// larger program
+			if strings.HasPrefix(terr.Msg, "\t") || terr.Soft && inBody {
+				return
+			}
+			pos := terr.Fset.Position(terr.Pos)
+			errs = append(errs, ValidationError{
+				ProverbID: id,
+				Field:     "Example",
+				File:      layout.file,
+				Line:      pos.Line,
+				Column:    pos.Column,
+				Message:   terr.Msg,
+			})
+		},
+	}
+
+	checkMu.Lock()
+	defer checkMu.Unlock()
+	conf.Check(examplePackage, fset, []*ast.File{f}, nil)
+	return errs
+}
+
+// missingImports returns the import paths of packages a fragment refers to
+// by name without declaring them, looking names up in the declared imports
+// before the standard library
+func missingImports(f *ast.File, declared []string) []string {
+	var imports []string
+	for _, ident := range f.Unresolved {
+		path, ok := stdPackages[ident.Name]
+		if i := slices.IndexFunc(declared, func(imp string) bool { return importName(imp) == ident.Name }); i >= 0 {
+			path, ok = declared[i], true
+		}
+		if ok && !slices.Contains(imports, path) {
+			imports = append(imports, path)
+		}
+	}
+	slices.Sort(imports)
+	return imports
+}
+
+// importName returns the name a package is usually imported under: the
+// last element of its path, skipping a major version suffix such as v2
+func importName(importPath string) string {
+	dir, name := path.Split(importPath)
+	if dir != "" && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		return path.Base(dir)
+	}
+	return name
+}
+
+// syntaxErrors converts parse errors into validation errors
+func syntaxErrors(id string, err error) []ValidationError {
+	var list scanner.ErrorList
+	if !errors.As(err, &list) {
+		return []ValidationError{{ProverbID: id, Field: "Example", Message: err.Error()}}
+	}
+	errs := make([]ValidationError, 0, len(list))
+	for _, e := range list {
+		errs = append(errs, ValidationError{
+			ProverbID: id,
+			Field:     "Example",
+			File:      e.Pos.Filename,
+			Line:      e.Pos.Line,
+			Column:    e.Pos.Column,
+			Message:   e.Msg,
+		})
+	}
+	return errs
+}
@@ -1,9 +1,5 @@
 package proverbs
 
-import (
-	"time"
-)
-
 // GetCommunityProverbs returns additional proverbs contributed by the Go community
 func GetCommunityProverbs() map[string]Proverb {
 	return map[string]Proverb{
@@ -15,7 +11,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-001"),
 			Explanation: "Context should be the first parameter in functions that can be cancelled or have timeouts. It enables proper cancellation propagation and request scoping.",
 			Tags:        []string{"context", "cancellation", "timeouts"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-002": {
@@ -26,7 +21,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-002"),
 			Explanation: "Table-driven tests allow you to test multiple scenarios with the same test logic, making tests more maintainable and comprehensive.",
 			Tags:        []string{"testing", "table-driven", "coverage"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-003": {
@@ -37,7 +31,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-003"),
 			Explanation: "Input validation should happen at system boundaries (API endpoints, function entry points) to catch errors early and provide clear feedback.",
 			Tags:        []string{"validation", "boundaries", "input"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-004": {
@@ -48,7 +41,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-004"),
 			Explanation: "Functional options provide a clean, extensible way to configure complex objects while maintaining backward compatibility.",
 			Tags:        []string{"options", "constructor", "configuration"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-005": {
@@ -59,7 +51,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-005"),
 			Explanation: "Go's embedding promotes composition over inheritance, leading to more flexible and maintainable code structures.",
 			Tags:        []string{"embedding", "composition", "design"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-006": {
@@ -70,7 +61,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-006"),
 			Explanation: "Channels excel at coordinating goroutines and passing data, while mutexes are better for protecting shared state.",
 			Tags:        []string{"channels", "mutexes", "coordination"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-007": {
@@ -81,7 +71,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-007"),
 			Explanation: "Custom error types enable better error handling, type checking, and provide more context than simple string errors.",
 			Tags:        []string{"errors", "types", "handling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-008": {
@@ -92,7 +81,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-008"),
 			Explanation: "Build tags allow you to include or exclude code based on build conditions, useful for platform-specific code or feature flags.",
 			Tags:        []string{"build-tags", "conditional", "compilation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-009": {
@@ -103,7 +91,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-009"),
 			Explanation: "sync.Once ensures expensive initialization happens exactly once, even in concurrent environments.",
 			Tags:        []string{"sync.Once", "initialization", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-010": {
@@ -114,7 +101,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-010"),
 			Explanation: "Type switches provide a clean way to handle different concrete types that implement the same interface.",
 			Tags:        []string{"type-switch", "interfaces", "handling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-011": {
@@ -125,7 +111,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-011"),
 			Explanation: "Design your types so that their zero value is useful and ready to use without explicit initialization.",
 			Tags:        []string{"zero-value", "design", "initialization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-012": {
@@ -136,7 +121,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-012"),
 			Explanation: "Functions should accept interfaces for flexibility and return concrete types for clarity and performance.",
 			Tags:        []string{"interfaces", "structs", "design"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-013": {
@@ -147,7 +131,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-013"),
 			Explanation: "Replace magic strings and numbers with named constants to improve code readability and maintainability.",
 			Tags:        []string{"constants", "magic-values", "readability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-014": {
@@ -158,7 +141,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-014"),
 			Explanation: "Use defer for cleanup operations like closing files or releasing resources, not for complex control flow logic.",
 			Tags:        []string{"defer", "cleanup", "control-flow"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-015": {
@@ -169,7 +151,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-015"),
 			Explanation: "Buffered channels can improve performance by allowing producers and consumers to work at different rates.",
 			Tags:        []string{"buffered-channels", "async", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-016": {
@@ -180,7 +161,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-016"),
 			Explanation: "Preallocating slices with known capacity avoids multiple memory allocations and improves performance.",
 			Tags:        []string{"slices", "preallocation", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-017": {
@@ -191,7 +171,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-017"),
 			Explanation: "Worker pools limit the number of concurrent operations, preventing resource exhaustion and improving system stability.",
 			Tags:        []string{"worker-pools", "concurrency", "bounded"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-018": {
@@ -202,7 +181,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-018"),
 			Explanation: "sync.Pool allows reusing expensive objects across goroutines, reducing garbage collection pressure.",
 			Tags:        []string{"sync.Pool", "object-reuse", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-019": {
@@ -213,7 +191,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-019"),
 			Explanation: "Production caches should have TTL for data freshness and size limits to prevent memory exhaustion.",
 			Tags:        []string{"cache", "TTL", "size-limits"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-020": {
@@ -224,7 +201,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-020"),
 			Explanation: "Structured logging with key-value pairs makes logs searchable and easier to analyze in production systems.",
 			Tags:        []string{"logging", "structured", "production"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-021": {
@@ -235,7 +211,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-021"),
 			Explanation: "Health checks enable monitoring systems to detect service issues and take appropriate action.",
 			Tags:        []string{"health-checks", "monitoring", "services"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-022": {
@@ -246,7 +221,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-022"),
 			Explanation: "Comprehensive observability requires metrics for quantitative data, tracing for request flow, and structured logging for detailed context.",
 			Tags:        []string{"observability", "metrics", "tracing", "logging"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-023": {
@@ -257,7 +231,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-023"),
 			Explanation: "Graceful shutdown ensures that services can complete ongoing work and clean up resources before terminating.",
 			Tags:        []string{"graceful-shutdown", "services", "cleanup"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-024": {
@@ -268,7 +241,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-024"),
 			Explanation: "Select statements enable non-blocking channel operations and timeouts, preventing goroutines from hanging indefinitely.",
 			Tags:        []string{"select", "non-blocking", "timeouts"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-025": {
@@ -279,7 +251,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-025"),
 			Explanation: "Fan-out distributes work across multiple goroutines, fan-in collects results, maximizing parallelism and throughput.",
 			Tags:        []string{"fan-out", "fan-in", "parallel"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-026": {
@@ -290,7 +261,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-026"),
 			Explanation: "Context carries request-scoped values like user IDs, trace IDs, and authentication tokens across API boundaries.",
 			Tags:        []string{"context", "request-scoped", "values"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-027": {
@@ -301,7 +271,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-027"),
 			Explanation: "Pipelines chain processing stages with channels, enabling concurrent data transformation with clear separation of concerns.",
 			Tags:        []string{"pipeline", "transformation", "stages"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-028": {
@@ -312,7 +281,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-028"),
 			Explanation: "WaitGroup synchronizes completion of multiple goroutines, ensuring all work finishes before proceeding.",
 			Tags:        []string{"sync.WaitGroup", "coordination", "synchronization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-029": {
@@ -323,7 +291,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-029"),
 			Explanation: "Ticker-based rate limiting controls the frequency of operations, preventing system overload and ensuring fair resource usage.",
 			Tags:        []string{"rate-limiting", "ticker", "throttling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-030": {
@@ -334,7 +301,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-030"),
 			Explanation: "Circuit breakers prevent cascading failures by temporarily blocking calls to failing services, allowing them time to recover.",
 			Tags:        []string{"circuit-breaker", "resilience", "failure-handling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-031": {
@@ -345,7 +311,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-031"),
 			Explanation: "Atomic operations provide lock-free synchronization for simple operations, offering better performance than mutexes for basic counters.",
 			Tags:        []string{"atomic", "lock-free", "counters"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-032": {
@@ -356,7 +321,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-032"),
 			Explanation: "Semaphores limit the number of concurrent operations accessing a shared resource, preventing resource exhaustion.",
 			Tags:        []string{"semaphore", "resource-limiting", "concurrency-control"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-033": {
@@ -367,7 +331,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-033"),
 			Explanation: "errgroup simplifies error handling and cancellation in concurrent operations, automatically canceling remaining work on first error.",
 			Tags:        []string{"errgroup", "error-handling", "cancellation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-034": {
@@ -378,7 +341,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-034"),
 			Explanation: "Timeout patterns prevent operations from running indefinitely, ensuring system responsiveness and resource cleanup.",
 			Tags:        []string{"timeout", "context", "responsiveness"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-035": {
@@ -389,7 +351,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-035"),
 			Explanation: "sync.Map provides concurrent-safe map operations optimized for read-heavy workloads with occasional writes.",
 			Tags:        []string{"sync.Map", "concurrent-map", "thread-safe"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-036": {
@@ -400,7 +361,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-036"),
 			Explanation: "Pub-sub patterns decouple message producers from consumers, enabling flexible event-driven architectures.",
 			Tags:        []string{"pub-sub", "event-driven", "decoupling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-037": {
@@ -411,7 +371,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-037"),
 			Explanation: "sync.Cond enables complex synchronization scenarios where goroutines wait for specific conditions to become true.",
 			Tags:        []string{"sync.Cond", "condition-variables", "synchronization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-038": {
@@ -422,7 +381,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-038"),
 			Explanation: "Memory pools reduce GC pressure by reusing objects, crucial for high-throughput applications with frequent allocations.",
 			Tags:        []string{"memory-pooling", "gc-optimization", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-039": {
@@ -433,7 +391,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-039"),
 			Explanation: "strings.Builder provides efficient string concatenation by minimizing memory allocations and copies.",
 			Tags:        []string{"string-builder", "concatenation", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-040": {
@@ -444,7 +401,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-040"),
 			Explanation: "Reslicing can cause memory leaks by retaining references to large underlying arrays. Copy when the slice is much smaller.",
 			Tags:        []string{"memory-leaks", "slice-reslicing", "gc"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-041": {
@@ -455,7 +411,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-041"),
 			Explanation: "unsafe package enables zero-copy operations and memory layout control, but use sparingly and with extreme caution.",
 			Tags:        []string{"unsafe", "zero-copy", "performance-critical"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-042": {
@@ -466,7 +421,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-042"),
 			Explanation: "Profile before optimizing. pprof identifies actual bottlenecks rather than assumed ones, guiding effective optimization efforts.",
 			Tags:        []string{"profiling", "pprof", "optimization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-043": {
@@ -477,7 +431,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-043"),
 			Explanation: "Build constraints enable compile-time feature flags, allowing different builds for different environments or feature sets.",
 			Tags:        []string{"build-constraints", "feature-flags", "conditional-compilation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-044": {
@@ -488,7 +441,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-044"),
 			Explanation: "Small, focused interfaces are easier to mock and test, following the interface segregation principle for better testability.",
 			Tags:        []string{"interface-segregation", "testability", "mocking"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-045": {
@@ -499,7 +451,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-045"),
 			Explanation: "testify provides rich assertions and mocking capabilities, making tests more readable and maintainable than basic if statements.",
 			Tags:        []string{"testify", "assertions", "testing-framework"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-046": {
@@ -510,7 +461,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-046"),
 			Explanation: "Test helpers with t.Helper() and t.Cleanup() reduce duplication and ensure proper resource cleanup in tests.",
 			Tags:        []string{"test-helpers", "cleanup", "duplication"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-047": {
@@ -521,7 +471,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-047"),
 			Explanation: "Golden files store expected complex outputs, making it easy to test and update complex string or binary outputs.",
 			Tags:        []string{"golden-files", "output-testing", "testdata"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-048": {
@@ -532,7 +481,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-048"),
 			Explanation: "Benchmarks should use realistic data sizes and patterns to provide meaningful performance insights for production scenarios.",
 			Tags:        []string{"benchmarking", "realistic-data", "performance-testing"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-049": {
@@ -543,7 +491,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-049"),
 			Explanation: "Dependency injection makes code more testable, flexible, and follows the dependency inversion principle.",
 			Tags:        []string{"dependency-injection", "testability", "flexibility"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-050": {
@@ -554,7 +501,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-050"),
 			Explanation: "Repository pattern abstracts data access, enabling easy testing and switching between different storage implementations.",
 			Tags:        []string{"repository-pattern", "data-access", "abstraction"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-051": {
@@ -565,7 +511,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-051"),
 			Explanation: "Command pattern encapsulates operations as objects, enabling undo/redo functionality and operation queuing.",
 			Tags:        []string{"command-pattern", "undo-redo", "operations"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-052": {
@@ -576,7 +521,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-052"),
 			Explanation: "Strategy pattern enables runtime algorithm selection, making code more flexible and following the open/closed principle.",
 			Tags:        []string{"strategy-pattern", "algorithm-selection", "flexibility"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-053": {
@@ -587,7 +531,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-053"),
 			Explanation: "Observer pattern enables loose coupling between event producers and consumers, supporting reactive programming patterns.",
 			Tags:        []string{"observer-pattern", "event-handling", "reactive"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-054": {
@@ -598,7 +541,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-054"),
 			Explanation: "Decorator pattern enables composable middleware chains, adding cross-cutting concerns without modifying core logic.",
 			Tags:        []string{"decorator-pattern", "middleware", "cross-cutting"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-055": {
@@ -609,7 +551,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-055"),
 			Explanation: "Factory pattern abstracts object creation, enabling runtime type selection and configuration-based instantiation.",
 			Tags:        []string{"factory-pattern", "object-creation", "abstraction"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-056": {
@@ -620,7 +561,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-056"),
 			Explanation: "Channels are first-class values that can be passed, stored, and manipulated, enabling powerful concurrent patterns.",
 			Tags:        []string{"channels", "first-class", "composition"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-057": {
@@ -631,7 +571,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-057"),
 			Explanation: "Context enables cancellation to propagate through call stacks and goroutine hierarchies, ensuring clean shutdown.",
 			Tags:        []string{"cancellation", "context", "propagation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-058": {
@@ -642,7 +581,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-058"),
 			Explanation: "Reflection is expensive; cache reflection results and use code generation when possible for better performance.",
 			Tags:        []string{"reflection", "caching", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-059": {
@@ -653,7 +591,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-059"),
 			Explanation: "Understanding escape analysis helps write allocation-efficient code by keeping values on the stack when possible.",
 			Tags:        []string{"escape-analysis", "stack-allocation", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-060": {
@@ -664,7 +601,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-060"),
 			Explanation: "go:generate automates code generation, reducing boilerplate and ensuring generated code stays in sync with source.",
 			Tags:        []string{"go:generate", "code-generation", "automation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-061": {
@@ -675,7 +611,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-061"),
 			Explanation: "Graceful degradation ensures systems remain functional even when dependencies fail, improving overall reliability.",
 			Tags:        []string{"graceful-degradation", "fallbacks", "reliability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-062": {
@@ -686,7 +621,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-062"),
 			Explanation: "Bulkhead pattern isolates resources to prevent failures in one area from affecting others, improving system resilience.",
 			Tags:        []string{"bulkhead-pattern", "fault-isolation", "resilience"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-063": {
@@ -697,7 +631,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-063"),
 			Explanation: "Exponential backoff prevents overwhelming failing services while providing reasonable retry behavior for transient failures.",
 			Tags:        []string{"retry", "exponential-backoff", "resilience"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-064": {
@@ -708,7 +641,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-064"),
 			Explanation: "Context deadlines ensure operations don't run indefinitely, providing automatic timeout and cancellation capabilities.",
 			Tags:        []string{"context", "deadlines", "cancellation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-065": {
@@ -719,7 +651,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-065"),
 			Explanation: "Use strings.Join() or pre-sized strings.Builder for efficient string operations, avoiding repeated concatenation.",
 			Tags:        []string{"string-operations", "memory-efficiency", "performance"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-066": {
@@ -730,7 +661,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-066"),
 			Explanation: "RWMutex allows multiple concurrent readers while ensuring exclusive access for writers, improving performance for read-heavy scenarios.",
 			Tags:        []string{"sync.RWMutex", "read-heavy", "concurrency"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-067": {
@@ -741,7 +671,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-067"),
 			Explanation: "Bounded queues with timeouts prevent memory exhaustion when producers outpace consumers, providing backpressure.",
 			Tags:        []string{"bounded-queues", "backpressure", "memory-safety"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-068": {
@@ -752,7 +681,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-068"),
 			Explanation: "Define interfaces at testing boundaries to enable easy mocking and isolation of external dependencies.",
 			Tags:        []string{"testing-boundaries", "interfaces", "mocking"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-069": {
@@ -763,7 +691,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-069"),
 			Explanation: "Early validation and fast failure prevent invalid data from propagating through the system, making debugging easier.",
 			Tags:        []string{"validation", "fail-fast", "error-handling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-070": {
@@ -774,7 +701,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-070"),
 			Explanation: "Type aliases provide type safety and domain clarity, preventing parameter mix-ups and making code more self-documenting.",
 			Tags:        []string{"type-aliases", "domain-modeling", "type-safety"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-071": {
@@ -785,7 +711,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-071"),
 			Explanation: "Done channels provide explicit lifecycle management for goroutines, enabling clean shutdown and resource cleanup.",
 			Tags:        []string{"done-channel", "lifecycle", "goroutine-management"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-072": {
@@ -796,7 +721,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-072"),
 			Explanation: "Backpressure mechanisms prevent fast producers from overwhelming slow consumers, maintaining system stability.",
 			Tags:        []string{"backpressure", "flow-control", "stability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-073": {
@@ -807,7 +731,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-073"),
 			Explanation: "sync.Cond enables complex synchronization where goroutines wait for specific conditions, more flexible than simple channels.",
 			Tags:        []string{"sync.Cond", "complex-coordination", "condition-variables"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-074": {
@@ -818,7 +741,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-074"),
 			Explanation: "Signal handling enables zero-downtime deployments and graceful restarts, essential for production services.",
 			Tags:        []string{"signal-handling", "graceful-restart", "zero-downtime"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-075": {
@@ -829,7 +751,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-075"),
 			Explanation: "Use typed keys and accessor functions for context values to prevent key collisions and provide type safety.",
 			Tags:        []string{"context-values", "type-safety", "accessor-functions"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-076": {
@@ -840,7 +761,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-076"),
 			Explanation: "Distributed tracing through context enables request flow visibility across microservices and system boundaries.",
 			Tags:        []string{"distributed-tracing", "observability", "microservices"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-077": {
@@ -851,7 +771,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-077"),
 			Explanation: "Interface embedding creates composite interfaces from smaller ones, following the interface segregation principle.",
 			Tags:        []string{"interface-embedding", "composition", "segregation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-078": {
@@ -862,7 +781,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-078"),
 			Explanation: "Saga pattern manages distributed transactions through compensating actions, ensuring eventual consistency.",
 			Tags:        []string{"saga-pattern", "distributed-transactions", "eventual-consistency"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-079": {
@@ -873,7 +791,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-079"),
 			Explanation: "CQRS separates read and write models, enabling independent scaling and optimization of each concern.",
 			Tags:        []string{"CQRS", "read-write-separation", "scaling"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-080": {
@@ -884,7 +801,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-080"),
 			Explanation: "Event sourcing stores state changes as events, providing complete audit trails and enabling temporal queries.",
 			Tags:        []string{"event-sourcing", "audit-trail", "temporal-queries"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-081": {
@@ -895,7 +811,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-081"),
 			Explanation: "Hexagonal architecture isolates business logic from external concerns, making code highly testable and maintainable.",
 			Tags:        []string{"hexagonal-architecture", "ports-adapters", "testability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-082": {
@@ -906,7 +821,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-082"),
 			Explanation: "Domain events enable loose coupling between bounded contexts and support eventual consistency in distributed systems.",
 			Tags:        []string{"domain-events", "loose-coupling", "bounded-contexts"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-083": {
@@ -917,7 +831,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-083"),
 			Explanation: "Value objects encapsulate domain concepts with validation and behavior, ensuring invariants and preventing invalid states.",
 			Tags:        []string{"value-objects", "domain-modeling", "invariants"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-084": {
@@ -928,7 +841,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-084"),
 			Explanation: "Aggregate roots define consistency boundaries and ensure business rules are enforced within the aggregate.",
 			Tags:        []string{"aggregate-roots", "consistency-boundaries", "business-rules"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-085": {
@@ -939,7 +851,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-085"),
 			Explanation: "Specification pattern encapsulates business rules as composable objects, enabling complex query logic reuse.",
 			Tags:        []string{"specification-pattern", "business-rules", "composable-queries"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-086": {
@@ -950,7 +861,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-086"),
 			Explanation: "Outbox pattern ensures reliable message publishing by storing events in the same transaction as business data.",
 			Tags:        []string{"outbox-pattern", "reliable-messaging", "transactional-outbox"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-087": {
@@ -961,7 +871,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-087"),
 			Explanation: "Property-based testing generates random inputs to verify invariants, finding edge cases that example-based tests miss.",
 			Tags:        []string{"property-based-testing", "edge-cases", "invariants"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-088": {
@@ -972,7 +881,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-088"),
 			Explanation: "Contract testing ensures API compatibility between services without requiring integration test environments.",
 			Tags:        []string{"contract-testing", "microservices", "api-compatibility"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-089": {
@@ -983,7 +891,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-089"),
 			Explanation: "Mutation testing evaluates test quality by introducing bugs and checking if tests catch them, revealing weak test coverage.",
 			Tags:        []string{"mutation-testing", "test-quality", "coverage-analysis"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-090": {
@@ -994,7 +901,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-090"),
 			Explanation: "Chaos engineering intentionally introduces failures to test system resilience and discover weaknesses before they cause outages.",
 			Tags:        []string{"chaos-engineering", "resilience-testing", "failure-injection"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-091": {
@@ -1005,7 +911,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-091"),
 			Explanation: "Fuzzing generates random inputs to find crashes, security vulnerabilities, and edge cases in parsing and validation code.",
 			Tags:        []string{"fuzzing", "security-testing", "vulnerability-discovery"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-092": {
@@ -1016,7 +921,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-092"),
 			Explanation: "Load shedding protects services from overload by rejecting requests when capacity is exceeded, maintaining service for accepted requests.",
 			Tags:        []string{"load-shedding", "overload-protection", "capacity-management"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-093": {
@@ -1027,7 +931,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-093"),
 			Explanation: "Adaptive timeouts adjust based on observed latency patterns, providing better resilience than fixed timeouts.",
 			Tags:        []string{"adaptive-timeouts", "latency-based", "dynamic-configuration"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-094": {
@@ -1038,7 +941,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-094"),
 			Explanation: "Request deduplication ensures idempotency by caching results of operations, preventing duplicate processing.",
 			Tags:        []string{"deduplication", "idempotency", "request-caching"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-095": {
@@ -1049,7 +951,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example: GetExampleForProverb("community-095"),
 			Explanation: "Proper connection pool configuration balances resource usage with performance, preventing connection exhaustion.",
 			Tags:        []string{"connection-pooling", "database-optimization", "resource-management"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-096": {
@@ -1060,7 +961,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-096"),
 			Explanation: "Batch processing reduces overhead by grouping operations, improving throughput for high-volume scenarios.",
 			Tags:        []string{"batch-processing", "throughput-optimization", "bulk-operations"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-097": {
@@ -1071,7 +971,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-097"),
 			Explanation: "Streaming processes data incrementally without loading everything into memory, enabling handling of arbitrarily large datasets.",
 			Tags:        []string{"streaming", "memory-efficiency", "large-data"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-098": {
@@ -1082,7 +981,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-098"),
 			Explanation: "Zero-allocation string operations avoid unnecessary memory allocations, improving performance in hot paths.",
 			Tags:        []string{"zero-allocation", "string-optimization", "hot-path"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-099": {
@@ -1093,7 +991,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-099"),
 			Explanation: "CPU profiling reveals actual performance bottlenecks, guiding optimization efforts to where they'll have the most impact.",
 			Tags:        []string{"cpu-profiling", "bottleneck-identification", "performance-analysis"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-100": {
@@ -1104,7 +1001,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-100"),
 			Explanation: "Memory-efficient data structures reduce GC pressure and improve cache locality, especially important for high-throughput applications.",
 			Tags:        []string{"memory-efficiency", "data-structures", "gc-optimization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-101": {
@@ -1115,7 +1011,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-101"),
 			Explanation: "Channels provide a safer and more idiomatic way to coordinate goroutines than shared memory with locks.",
 			Tags:        []string{"channels", "communication", "goroutines", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-102": {
@@ -1126,7 +1021,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-102"),
 			Explanation: "Concurrency is about structure and composition, while parallelism is about execution. Go enables both.",
 			Tags:        []string{"concurrency", "parallelism", "goroutines", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-103": {
@@ -1137,7 +1031,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-103"),
 			Explanation: "Use channels for coordination and workflow, mutexes for protecting shared state. Each has its place.",
 			Tags:        []string{"channels", "mutexes", "orchestration", "serialization"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-104": {
@@ -1148,7 +1041,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-104"),
 			Explanation: "Small, focused interfaces are more flexible, testable, and easier to implement than large, monolithic ones.",
 			Tags:        []string{"interfaces", "abstraction", "design", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-105": {
@@ -1159,7 +1051,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-105"),
 			Explanation: "Focus on correctness first, then clean design, and finally performance. Premature optimization is the root of all evil.",
 			Tags:        []string{"development-process", "optimization", "design", "kent-beck"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-106": {
@@ -1170,7 +1061,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-106"),
 			Explanation: "Go treats errors as ordinary values, not exceptions. This makes error handling explicit and composable.",
 			Tags:        []string{"errors", "values", "error-handling", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-107": {
@@ -1181,7 +1071,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-107"),
 			Explanation: "Use panic only for truly unrecoverable situations. Return errors for conditions that callers can handle.",
 			Tags:        []string{"panic", "errors", "error-handling", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-108": {
@@ -1192,7 +1081,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-108"),
 			Explanation: "Good software starts with clear architecture, uses meaningful names, and documents the reasoning behind decisions.",
 			Tags:        []string{"architecture", "naming", "documentation", "design"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-109": {
@@ -1203,7 +1091,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-109"),
 			Explanation: "Write documentation from the user's perspective, focusing on what they need to know, not how it works internally.",
 			Tags:        []string{"documentation", "user-focused", "api-design"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-110": {
@@ -1214,7 +1101,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-110"),
 			Explanation: "Error handling should add context, enable recovery, and help with debugging. Don't just log and ignore.",
 			Tags:        []string{"error-handling", "graceful-degradation", "dave-cheney"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-111": {
@@ -1225,7 +1111,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-111"),
 			Explanation: "Design APIs and code paths that reduce the number of possible error conditions rather than just handling them.",
 			Tags:        []string{"error-elimination", "api-design", "dave-cheney"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-112": {
@@ -1236,7 +1121,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-112"),
 			Explanation: "Keep imports clean and minimal. Unused imports add confusion and increase compilation time.",
 			Tags:        []string{"imports", "clean-code", "compilation"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-113": {
@@ -1247,7 +1131,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-113"),
 			Explanation: "Consistent formatting is more important than personal preferences. gofmt eliminates style debates and makes code uniform.",
 			Tags:        []string{"gofmt", "formatting", "consistency", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-114": {
@@ -1258,7 +1141,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-114"),
 			Explanation: "Small amounts of duplication can be preferable to adding dependencies, especially for simple utility functions.",
 			Tags:        []string{"dependencies", "copying", "duplication", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-115": {
@@ -1269,7 +1151,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-115"),
 			Explanation: "Platform-specific code should be isolated with build tags to ensure cross-platform compatibility.",
 			Tags:        []string{"syscall", "build-tags", "cross-platform"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-116": {
@@ -1280,7 +1161,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-116"),
 			Explanation: "Cgo code should be optional with pure Go fallbacks to maintain portability and reduce build complexity.",
 			Tags:        []string{"cgo", "build-tags", "portability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-117": {
@@ -1291,7 +1171,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-117"),
 			Explanation: "Cgo sacrifices many of Go's advantages. Use it sparingly and only when pure Go solutions aren't viable.",
 			Tags:        []string{"cgo", "pure-go", "cross-compilation", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-118": {
@@ -1302,7 +1181,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-118"),
 			Explanation: "The unsafe package bypasses Go's type safety and memory safety. Use only when absolutely necessary and with extreme caution.",
 			Tags:        []string{"unsafe", "safety", "performance", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-119": {
@@ -1313,7 +1191,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-119"),
 			Explanation: "Write code for humans to read. Clarity and maintainability are more important than showing off clever tricks.",
 			Tags:        []string{"clarity", "readability", "maintainability"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-120": {
@@ -1324,7 +1201,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-120"),
 			Explanation: "Reflection makes code harder to understand and maintain. Use it only when compile-time solutions aren't possible.",
 			Tags:        []string{"reflection", "clarity", "performance", "rob-pike"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-121": {
@@ -1335,7 +1211,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-121"),
 			Explanation: "Don't depend on error message content. Use error types, wrapping, and errors.Is/As for robust error handling.",
 			Tags:        []string{"error-opacity", "error-types", "dave-cheney"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-122": {
@@ -1346,7 +1221,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-122"),
 			Explanation: "Check what an error can do (behavior) rather than what it is (type). This creates more flexible error handling.",
 			Tags:        []string{"error-behavior", "interfaces", "dave-cheney"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-123": {
@@ -1357,7 +1231,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-123"),
 			Explanation: "Every error represents a potential failure mode. Handle them appropriately or explicitly acknowledge ignoring them.",
 			Tags:        []string{"error-handling", "reliability", "explicit-ignore"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 		"community-124": {
@@ -1368,7 +1241,6 @@ func GetCommunityProverbs() map[string]Proverb {
 			Example:     GetExampleForProverb("community-124"),
 			Explanation: "Low-level functions should add context and propagate errors. High-level functions should decide on recovery strategies.",
 			Tags:        []string{"error-levels", "context", "recovery-strategy"},
-			CreatedAt:   time.Now(),
 			Source:      SourceCommunity,
 		},
 	}
@@ -0,0 +1,229 @@
+package proverbs
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// CompletionKind says what a completion completes to
+type CompletionKind string
+
+const (
+	CompleteTitle    CompletionKind = "title"
+	CompleteTag      CompletionKind = "tag"
+	CompleteCategory CompletionKind = "category"
+)
+
+// DefaultCompletionLimit is how many completions Complete returns when no
+// positive limit is given
+const DefaultCompletionLimit = 8
+
+// Completion is a title, tag or category the typed prefix completes to.
+// Titles carry the proverb's ID and popularity; tags and categories carry
+// how many proverbs use them.
+type Completion struct {
+	Kind       CompletionKind `json:"kind"`
+	Value      string         `json:"value"`
+	ID         string         `json:"id,omitempty"`
+	Popularity int            `json:"popularity,omitempty"`
+	Count      int            `json:"count,omitempty"`
+
+	match matchQuality
+}
+
+// weight orders completions of the same match quality, most used first
+func (c Completion) weight() int {
+	if c.Kind == CompleteTitle {
+		return c.Popularity
+	}
+	return c.Count
+}
+
+// matchQuality ranks how well a prefix matches a value. Lower is better.
+type matchQuality int
+
+const (
+	matchExact     matchQuality = iota // the whole value
+	matchStart                         // the start of the value
+	matchWordStart                     // the start of a later word
+)
+
+// Completer is implemented by stores that keep their own completion trie
+type Completer interface {
+	Complete(prefix string, limit int) ([]Completion, error)
+}
+
+// Complete returns up to limit completions for prefix from the store, using
+// its own trie when it has one and building a temporary index otherwise
+func Complete(store Store, prefix string, limit int) ([]Completion, error) {
+	if completer, ok := store.(Completer); ok {
+		return completer.Complete(prefix, limit)
+	}
+	all, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	return NewSearchIndex(all).Complete(prefix, limit), nil
+}
+
+// Complete returns up to limit completions for prefix: exact matches first,
+// then values starting with the prefix, then values with a later word
+// starting with it, each group most popular first
+func (idx *SearchIndex) Complete(prefix string, limit int) []Completion {
+	return rankCompletions(idx.completions.lookup(prefix), limit)
+}
+
+// rankCompletions sorts completions best first and keeps the first limit
+func rankCompletions(completions []Completion, limit int) []Completion {
+	if limit <= 0 {
+		limit = DefaultCompletionLimit
+	}
+	slices.SortFunc(completions, func(a, b Completion) int {
+		if c := cmp.Compare(a.match, b.match); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.weight(), a.weight()); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Kind, b.Kind); c != 0 {
+			return c
+		}
+		return cmp.Compare(strings.ToLower(a.Value), strings.ToLower(b.Value))
+	})
+	if len(completions) > limit {
+		completions = completions[:limit]
+	}
+	return completions
+}
+
+// completionTrie maps lowercase prefixes to the completions they match.
+// Every value is inserted from each of its word starts, so "han" finds
+// "Error handling" as well as "handler".
+type completionTrie struct {
+	root    trieNode
+	entries []Completion
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	matches  []trieMatch // values whose key ends at this node
+}
+
+// trieMatch is an entry reachable through a node and whether its key
+// started at the beginning of the value
+type trieMatch struct {
+	entry     int
+	wordStart bool
+}
+
+// newCompletionTrie indexes the titles, tags and categories of proverbs
+func newCompletionTrie(proverbs []Proverb) *completionTrie {
+	t := &completionTrie{}
+	tags := make(map[string]int)
+	categories := make(map[Category]int)
+	for _, p := range proverbs {
+		t.insert(Completion{Kind: CompleteTitle, Value: p.Title, ID: p.ID, Popularity: p.Popularity})
+		for _, tag := range p.Tags {
+			tags[tag]++
+		}
+		if p.Category != "" {
+			categories[p.Category]++
+		}
+	}
+	for tag, count := range tags {
+		t.insert(Completion{Kind: CompleteTag, Value: tag, Count: count})
+	}
+	for category, count := range categories {
+		t.insert(Completion{Kind: CompleteCategory, Value: string(category), Count: count})
+	}
+	return t
+}
+
+// insert adds a completion under its value and every later word of it
+func (t *completionTrie) insert(c Completion) {
+	key := normalizeCompletion(c.Value)
+	if key == "" {
+		return
+	}
+	entry := len(t.entries)
+	t.entries = append(t.entries, c)
+
+	prev := ' '
+	for i, r := range key {
+		if i == 0 || !isWordRune(prev) && isWordRune(r) {
+			t.root.add(key[i:], trieMatch{entry: entry, wordStart: i > 0})
+		}
+		prev = r
+	}
+}
+
+func (n *trieNode) add(key string, m trieMatch) {
+	for _, r := range key {
+		child, ok := n.children[r]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[rune]*trieNode)
+			}
+			child = &trieNode{}
+			n.children[r] = child
+		}
+		n = child
+	}
+	n.matches = append(n.matches, m)
+}
+
+// lookup returns every completion matching prefix, each once with its best
+// match quality
+func (t *completionTrie) lookup(prefix string) []Completion {
+	prefix = normalizeCompletion(prefix)
+	if t == nil || prefix == "" {
+		return nil
+	}
+
+	n := &t.root
+	for _, r := range prefix {
+		if n = n.children[r]; n == nil {
+			return nil
+		}
+	}
+
+	best := make(map[int]matchQuality)
+	var walk func(n *trieNode)
+	walk = func(n *trieNode) {
+		for _, m := range n.matches {
+			quality := matchStart
+			if m.wordStart {
+				quality = matchWordStart
+			} else if normalizeCompletion(t.entries[m.entry].Value) == prefix {
+				quality = matchExact
+			}
+			if q, ok := best[m.entry]; !ok || quality < q {
+				best[m.entry] = quality
+			}
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(n)
+
+	completions := make([]Completion, 0, len(best))
+	for entry, quality := range best {
+		c := t.entries[entry]
+		c.match = quality
+		completions = append(completions, c)
+	}
+	return completions
+}
+
+// normalizeCompletion lowercases s and collapses runs of whitespace so
+// typed prefixes match regardless of case and spacing
+func normalizeCompletion(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,424 @@
+package proverbs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DataFileExt is the extension of proverb data files. Each data file sits
+// next to the .gotmpl example with the same ID, e.g. official-001.yaml.
+const DataFileExt = ".yaml"
+
+// examplesRoot is the embedded directory holding the official and community
+// data files and examples
+const examplesRoot = "internal/proverbs/examples"
+
+// dataFS is the filesystem proverb data files are read from. When nil the
+// embedded examples directory is used.
+var dataFS fs.FS
+
+// SetDataFS sets the filesystem proverb data files are loaded from. The
+// filesystem must contain "official" and "community" directories.
+func SetDataFS(fsys fs.FS) {
+	dataFS = fsys
+}
+
+// DataError describes a problem found in a proverb data file
+type DataError struct {
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+func (de DataError) Error() string {
+	if de.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", de.File, de.Line, de.Message)
+	}
+	return fmt.Sprintf("%s: %s", de.File, de.Message)
+}
+
+// DataErrors is the list of errors found while loading a data directory
+type DataErrors []DataError
+
+func (de DataErrors) Error() string {
+	msgs := make([]string, len(de))
+	for i, err := range de {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// LoadFromFS loads proverbs from the data files found in the given
+// directories of fsys. Examples are executed as templates that can use the
+// snippets in the shared directory of fsys. Every file is parsed even after
+// a failure, so the returned DataErrors lists all problems at once.
+func LoadFromFS(fsys fs.FS, dirs ...string) (*ProverbCollection, error) {
+	collection := &ProverbCollection{
+		Official:  make(map[string]Proverb),
+		Community: make(map[string]Proverb),
+		UpdatedAt: time.Now(),
+		dataFS:    fsys,
+	}
+
+	shared, err := parseSharedTemplates(fsys, sharedDir)
+	if err != nil {
+		return nil, DataErrors{templateError(sharedDir, err)}
+	}
+
+	var errs DataErrors
+	found := 0
+	for _, dir := range dirs {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			return nil, fmt.Errorf("reading directory %s: %w", dir, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), DataFileExt) {
+				continue
+			}
+			found++
+
+			filePath := path.Join(dir, entry.Name())
+			data, err := fs.ReadFile(fsys, filePath)
+			if err != nil {
+				errs = append(errs, DataError{File: filePath, Message: err.Error()})
+				continue
+			}
+
+			proverb, err := ParseProverbFile(filePath, data)
+			if err != nil {
+				var de DataError
+				if errors.As(err, &de) {
+					errs = append(errs, de)
+				} else {
+					errs = append(errs, DataError{File: filePath, Message: err.Error()})
+				}
+				continue
+			}
+
+			id := strings.TrimSuffix(entry.Name(), DataFileExt)
+			proverb.ID = id
+			exampleFile := path.Join(dir, id+ExampleExt)
+			if src, err := fs.ReadFile(fsys, exampleFile); err == nil {
+				example, err := executeExample(shared, exampleFile, string(src), proverb)
+				if err != nil {
+					errs = append(errs, templateError(exampleFile, err))
+					continue
+				}
+				proverb.Example = example
+				proverb.templateLines = templateLines(string(src), example)
+			} else {
+				proverb.Example = GetExampleForProverb(id)
+			}
+			proverb.ExampleParts = ParseExample(proverb.Example)
+
+			switch proverb.Source {
+			case SourceOfficial:
+				collection.Official[id] = proverb
+			case SourceCommunity:
+				collection.Community[id] = proverb
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	if found == 0 {
+		return nil, fmt.Errorf("no %s data files found", DataFileExt)
+	}
+
+	return collection, nil
+}
+
+// ParseProverbFile parses a single proverb data file. The format is a small
+// subset of YAML: one "key: value" pair per line, with values either plain,
+// single- or double-quoted, and tags given as a flow list ("[a, b]") or as
+// a block list of "- item" lines. A proverb without created_at is left
+// undated, with a zero CreatedAt. Errors are returned as DataError values
+// carrying the offending line number.
+func ParseProverbFile(name string, data []byte) (Proverb, error) {
+	var proverb Proverb
+	seen := make(map[string]int)
+	listKey := ""
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" {
+			continue
+		}
+
+		fail := func(format string, args ...any) (Proverb, error) {
+			return Proverb{}, DataError{File: name, Line: lineNo, Message: fmt.Sprintf(format, args...)}
+		}
+
+		// Block list items continue the previous "key:" line
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if listKey == "" {
+				return fail("list item outside of a list")
+			}
+			item, err := parseScalar(strings.TrimSpace(strings.TrimPrefix(trimmed, "-")))
+			if err != nil {
+				return fail("%s: %v", listKey, err)
+			}
+			proverb.Tags = append(proverb.Tags, item)
+			continue
+		}
+		listKey = ""
+
+		if line != trimmed {
+			return fail("unexpected indentation")
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return fail("expected \"key: value\"")
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		if prev, dup := seen[key]; dup {
+			return fail("duplicate key %q (first set on line %d)", key, prev)
+		}
+		seen[key] = lineNo
+
+		if key == "tags" {
+			switch {
+			case value == "":
+				listKey = key
+			case strings.HasPrefix(value, "["):
+				tags, err := parseFlowList(value)
+				if err != nil {
+					return fail("tags: %v", err)
+				}
+				proverb.Tags = tags
+			default:
+				return fail("tags: expected a list")
+			}
+			continue
+		}
+
+		scalar, err := parseScalar(value)
+		if err != nil {
+			return fail("%s: %v", key, err)
+		}
+
+		switch key {
+		case "title":
+			proverb.Title = scalar
+		case "text":
+			proverb.Text = scalar
+		case "author":
+			proverb.Author = scalar
+		case "category":
+			proverb.Category = Category(scalar)
+		case "source":
+			proverb.Source = Source(scalar)
+		case "explanation":
+			proverb.Explanation = scalar
+		case "popularity":
+			n, err := strconv.Atoi(scalar)
+			if err != nil || n < 0 {
+				return fail("popularity: expected a non-negative integer, got %q", scalar)
+			}
+			proverb.Popularity = n
+		case "created_at":
+			t, err := parseDataTime(scalar)
+			if err != nil {
+				return fail("created_at: %v", err)
+			}
+			proverb.CreatedAt = t
+		default:
+			return fail("unknown key %q", key)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Proverb{}, DataError{File: name, Message: err.Error()}
+	}
+
+	for _, required := range []string{"title", "text", "source"} {
+		if _, ok := seen[required]; !ok {
+			return Proverb{}, DataError{File: name, Message: fmt.Sprintf("missing required key %q", required)}
+		}
+	}
+	if proverb.Source != SourceOfficial && proverb.Source != SourceCommunity {
+		return Proverb{}, DataError{File: name, Line: seen["source"], Message: fmt.Sprintf("invalid source: %s", proverb.Source)}
+	}
+	return proverb, nil
+}
+
+// FormatProverbFile renders a proverb in the data file format understood by
+// ParseProverbFile. The example is not included; it lives in its own file.
+func FormatProverbFile(proverb Proverb) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "title: %s\n", strconv.Quote(proverb.Title))
+	fmt.Fprintf(&buf, "text: %s\n", strconv.Quote(proverb.Text))
+	fmt.Fprintf(&buf, "author: %s\n", strconv.Quote(proverb.Author))
+	fmt.Fprintf(&buf, "category: %s\n", proverb.Category)
+	fmt.Fprintf(&buf, "source: %s\n", proverb.Source)
+	if !proverb.CreatedAt.IsZero() {
+		fmt.Fprintf(&buf, "created_at: %s\n", proverb.CreatedAt.Format(time.DateOnly))
+	}
+	if proverb.Popularity > 0 {
+		fmt.Fprintf(&buf, "popularity: %d\n", proverb.Popularity)
+	}
+	if proverb.Explanation != "" {
+		fmt.Fprintf(&buf, "explanation: %s\n", strconv.Quote(proverb.Explanation))
+	}
+	if len(proverb.Tags) > 0 {
+		tags := make([]string, len(proverb.Tags))
+		for i, tag := range proverb.Tags {
+			tags[i] = quoteIfNeeded(tag)
+		}
+		fmt.Fprintf(&buf, "tags: [%s]\n", strings.Join(tags, ", "))
+	}
+	return buf.Bytes()
+}
+
+// parseScalar decodes a plain, single-quoted or double-quoted scalar
+func parseScalar(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		s, err := strconv.Unquote(value)
+		if err != nil {
+			return "", fmt.Errorf("malformed double-quoted string")
+		}
+		return s, nil
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated single-quoted string")
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	default:
+		// Plain scalars end at a comment
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+		return value, nil
+	}
+}
+
+// parseFlowList decodes a "[a, b, c]" list of scalars
+func parseFlowList(value string) ([]string, error) {
+	if !strings.HasSuffix(value, "]") {
+		return nil, fmt.Errorf("unterminated list")
+	}
+	inner := strings.TrimSpace(value[1 : len(value)-1])
+	if inner == "" {
+		return nil, nil
+	}
+
+	var items []string
+	for _, part := range splitFlowItems(inner) {
+		item, err := parseScalar(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		if item == "" {
+			return nil, fmt.Errorf("empty list item")
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// splitFlowItems splits the inside of a flow list at the commas that are
+// not within a quoted item
+func splitFlowItems(inner string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(inner); i++ {
+		switch c := inner[i]; {
+		case quote == '"' && c == '\\':
+			i++ // skip the escaped character
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			parts = append(parts, inner[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, inner[start:])
+}
+
+// parseDataTime accepts either a date or a full RFC 3339 timestamp
+func parseDataTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected YYYY-MM-DD or RFC 3339 timestamp, got %q", value)
+	}
+	return t, nil
+}
+
+// quoteIfNeeded quotes list items that would not survive as plain scalars
+func quoteIfNeeded(s string) string {
+	if s == "" || strings.ContainsAny(s, ",[]#:'\"") || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// loadDataFiles loads the collection from the configured data filesystem
+func loadDataFiles() (*ProverbCollection, error) {
+	fsys, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFromFS(fsys, "official", "community")
+}
+
+// dataDir returns the filesystem proverb data files are read from
+func dataDir() (fs.FS, error) {
+	if dataFS != nil {
+		return dataFS, nil
+	}
+	return fs.Sub(exampleFS, examplesRoot)
+}
+
+// readDataDir returns the unexecuted example files of the data directory
+// fsys and whether each data file exists, both keyed by their path
+// relative to it, e.g. official/official-001.gotmpl and
+// official/official-001.yaml
+func readDataDir(fsys fs.FS) (examples map[string]string, dataFiles map[string]bool, err error) {
+	examples, dataFiles = make(map[string]string), make(map[string]bool)
+	for _, dir := range []string{string(SourceOfficial), string(SourceCommunity)} {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range entries {
+			file := path.Join(dir, entry.Name())
+			switch path.Ext(file) {
+			case DataFileExt:
+				dataFiles[file] = true
+			case ExampleExt:
+				src, err := fs.ReadFile(fsys, file)
+				if err != nil {
+					return nil, nil, err
+				}
+				examples[file] = string(src)
+			}
+		}
+	}
+	return examples, dataFiles, nil
+}
@@ -0,0 +1,58 @@
+package proverbs
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseFlowList(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{"[]", nil},
+		{"[a]", []string{"a"}},
+		{"[a, b ,c]", []string{"a", "b", "c"}},
+		{`["a, b", c]`, []string{"a, b", "c"}},
+		{`['a, b', 'it''s, ok']`, []string{"a, b", "it's, ok"}},
+		{`["say \"hi, there\"", x]`, []string{`say "hi, there"`, "x"}},
+		{`["a\\", b]`, []string{`a\`, "b"}},
+	}
+	for _, tt := range tests {
+		got, err := parseFlowList(tt.value)
+		if err != nil {
+			t.Errorf("parseFlowList(%q) error: %v", tt.value, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("parseFlowList(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestProverbFileRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		proverb Proverb
+	}{
+		{"plain", Proverb{Title: "Errors are values", Text: "Errors are values.", Author: "Rob Pike", Category: CategoryErrors, Source: "official", Tags: []string{"errors", "values"}}},
+		{"undated", Proverb{Title: "t", Text: "x", Category: CategoryDesign, Source: "community"}},
+		{"dated", Proverb{Title: "t", Text: "x", Category: CategoryDesign, Source: "community", CreatedAt: time.Date(2015, 11, 18, 0, 0, 0, 0, time.UTC), Popularity: 7}},
+		{"quoted tags", Proverb{Title: "t", Text: "x", Category: CategoryIdioms, Source: "community", Tags: []string{"a, b", "c", "it's", "x: y", "#1", `"q"`, " pad "}}},
+		{"quoted text", Proverb{Title: `Say "hi"`, Text: "a: b # c", Explanation: "one\ntwo", Category: CategoryIdioms, Source: "community"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := FormatProverbFile(tt.proverb)
+			got, err := ParseProverbFile("test.yaml", data)
+			if err != nil {
+				t.Fatalf("ParseProverbFile error: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(got, tt.proverb) {
+				t.Errorf("round trip = %+v, want %+v\n%s", got, tt.proverb, data)
+			}
+		})
+	}
+}
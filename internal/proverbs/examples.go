@@ -3,7 +3,11 @@ package proverbs
 import (
 	"embed"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
 	"strings"
+	"text/template"
 )
 
 // exampleFS will be set from the main package
@@ -14,9 +18,13 @@ func SetExampleFS(fs embed.FS) {
 	exampleFS = fs
 }
 
-// ExampleLoader handles loading examples from embedded files
+// ExampleLoader handles loading examples from embedded files. Examples are
+// text/template templates, executed with ExampleData and the snippets in
+// the shared directory.
 type ExampleLoader struct {
 	examples map[string]string
+	shared   *template.Template
+	errs     DataErrors
 }
 
 // NewExampleLoader creates a new example loader
@@ -34,6 +42,12 @@ func NewExampleLoader() (*ExampleLoader, error) {
 
 // loadExamples reads all .gotmpl files from the embedded filesystem
 func (el *ExampleLoader) loadExamples() error {
+	shared, err := parseSharedTemplates(exampleFS, path.Join(examplesRoot, sharedDir))
+	if err != nil {
+		return fmt.Errorf("loading shared templates: %w", err)
+	}
+	el.shared = shared
+
 	// Load official examples
 	if err := el.loadExamplesFromDir("internal/proverbs/examples/official"); err != nil {
 		return fmt.Errorf("loading official examples: %w", err)
@@ -47,7 +61,9 @@ func (el *ExampleLoader) loadExamples() error {
 	return nil
 }
 
-// loadExamplesFromDir loads examples from a specific directory
+// loadExamplesFromDir loads examples from a specific directory. Examples
+// whose template fails to parse or execute are left out and their errors
+// recorded.
 func (el *ExampleLoader) loadExamplesFromDir(dir string) error {
 	entries, err := exampleFS.ReadDir(dir)
 	if err != nil {
@@ -55,7 +71,7 @@ func (el *ExampleLoader) loadExamplesFromDir(dir string) error {
 	}
 
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".gotmpl") {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ExampleExt) {
 			continue
 		}
 
@@ -66,8 +82,15 @@ func (el *ExampleLoader) loadExamplesFromDir(dir string) error {
 		}
 
 		// Extract ID from filename (e.g., "official-001.gotmpl" -> "official-001")
-		id := strings.TrimSuffix(entry.Name(), ".gotmpl")
-		el.examples[id] = string(content)
+		id := strings.TrimSuffix(entry.Name(), ExampleExt)
+		source := Source(path.Base(dir))
+		file := path.Join(string(source), entry.Name())
+		example, err := executeExample(el.shared, file, string(content), Proverb{ID: id, Source: source})
+		if err != nil {
+			el.errs = append(el.errs, templateError(file, err))
+			continue
+		}
+		el.examples[id] = example
 	}
 
 	return nil
@@ -79,6 +102,20 @@ func (el *ExampleLoader) GetExample(proverbID string) (string, bool) {
 	return example, exists
 }
 
+// Errors returns the template errors of the examples that failed to load
+func (el *ExampleLoader) Errors() DataErrors {
+	return el.errs
+}
+
+// GetSections returns the example for a given proverb ID split into sections
+func (el *ExampleLoader) GetSections(proverbID string) (*Example, bool) {
+	example, exists := el.examples[proverbID]
+	if !exists {
+		return nil, false
+	}
+	return ParseExample(example), true
+}
+
 // GetAllExampleIDs returns all available example IDs
 func (el *ExampleLoader) GetAllExampleIDs() []string {
 	ids := make([]string, 0, len(el.examples))
@@ -147,3 +184,132 @@ func GetExampleForProverb(proverbID string) string {
 
 	return example
 }
+
+// SectionKind labels a part of an example
+type SectionKind string
+
+const (
+	SectionCode  SectionKind = "code" // code before the first marker
+	SectionBad   SectionKind = "bad"
+	SectionGood  SectionKind = "good"
+	SectionUsage SectionKind = "usage"
+	SectionNotes SectionKind = "notes"
+)
+
+// sectionKinds maps the lower-cased words of a section marker to the kind
+// of section it starts
+var sectionKinds = map[string]SectionKind{
+	"bad":        SectionBad,
+	"wrong":      SectionBad,
+	"unclear":    SectionBad,
+	"dangerous":  SectionBad,
+	"good":       SectionGood,
+	"better":     SectionGood,
+	"correct":    SectionGood,
+	"clear":      SectionGood,
+	"usage":      SectionUsage,
+	"note":       SectionNotes,
+	"notes":      SectionNotes,
+	"lesson":     SectionNotes,
+	"key points": SectionNotes,
+}
+
+// sectionMarker matches a comment such as "// BAD: Shared memory", "// Good
+// approach:" or "// Usage", capturing the marker words and the label
+var sectionMarker = regexp.MustCompile(`^//\s*([A-Za-z]+(?: [A-Za-z]+)?)\s*(?::\s*(.*))?$`)
+
+// Example is an example's front matter and the sections its comment
+// markers start
+type Example struct {
+	Meta     ExampleMeta      `json:"meta"`
+	Sections []ExampleSection `json:"sections"`
+}
+
+// ExampleSection is a labelled part of an example. Line is the line of the
+// example its code starts on.
+type ExampleSection struct {
+	Kind  SectionKind `json:"kind"`
+	Label string      `json:"label,omitempty"`
+	Code  string      `json:"code"`
+	Line  int         `json:"line"`
+}
+
+// HasVariants reports whether the example shows both a bad and a good
+// variant
+func (e *Example) HasVariants() bool {
+	if e == nil {
+		return false
+	}
+	has := func(kind SectionKind) bool {
+		return slices.ContainsFunc(e.Sections, func(s ExampleSection) bool { return s.Kind == kind })
+	}
+	return has(SectionBad) && has(SectionGood)
+}
+
+// ParseExample splits an example at comments like "// BAD:", "// GOOD:",
+// "// Usage:" and "// Note:". A marker only counts at the start of a line
+// and of a comment block, so markers inside doc comments and function
+// bodies stay part of their section. The front matter and "// Output:"
+// trailer are left out; front matter that does not parse is ignored here
+// and reported by ValidateCollection. It returns nil for an empty example.
+func ParseExample(src string) *Example {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+
+	meta, skip, _ := ParseFrontMatter(src)
+	lines := strings.Split(strings.TrimRight(src, " \t\r\n"), "\n")
+	if i := outputStart(lines); i >= 0 {
+		lines = lines[:i]
+	}
+
+	example := &Example{Meta: meta}
+	section := ExampleSection{Kind: SectionCode, Line: skip + 1}
+	var body []string
+	flush := func() {
+		for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+			body = body[1:]
+			section.Line++
+		}
+		for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+			body = body[:len(body)-1]
+		}
+		section.Code = strings.Join(body, "\n")
+		if section.Code != "" || section.Label != "" {
+			example.Sections = append(example.Sections, section)
+		}
+	}
+
+	for i, line := range lines {
+		if i < skip {
+			continue
+		}
+		line = strings.TrimSuffix(line, "\r")
+		if i > skip && strings.HasPrefix(strings.TrimSpace(lines[i-1]), "//") {
+			body = append(body, line)
+			continue
+		}
+		kind, label, ok := parseSectionMarker(line)
+		if !ok {
+			body = append(body, line)
+			continue
+		}
+		flush()
+		section = ExampleSection{Kind: kind, Label: label, Line: i + 2}
+		body = nil
+	}
+	flush()
+	return example
+}
+
+// parseSectionMarker returns the kind and label of the section a marker
+// comment starts
+func parseSectionMarker(line string) (SectionKind, string, bool) {
+	m := sectionMarker.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	words := strings.TrimSuffix(strings.ToLower(m[1]), " approach")
+	kind, ok := sectionKinds[words]
+	return kind, strings.TrimSpace(m[2]), ok
+}
@@ -0,0 +1,147 @@
+package proverbs
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FacetField names a proverb field results can be narrowed by
+type FacetField string
+
+const (
+	FacetCategory FacetField = "category"
+	FacetSource   FacetField = "source"
+	FacetAuthor   FacetField = "author"
+	FacetTag      FacetField = "tag"
+)
+
+// FacetFields lists every facet in display order
+var FacetFields = []FacetField{FacetCategory, FacetSource, FacetAuthor, FacetTag}
+
+// FacetValue is one value of a facet and how many results have it
+type FacetValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Facets holds the values of each facet, most common first
+type Facets map[FacetField][]FacetValue
+
+// FacetFilter restricts results to the selected values of each facet.
+// Values of the same facet are alternatives; different facets must all
+// match. An empty filter matches everything.
+type FacetFilter map[FacetField][]string
+
+// ParseFacetFilter reads facet filters from query parameters such as
+// category=errors&category=design&tag=goroutines. Parameters that are not
+// facets are ignored; empty values are skipped.
+func ParseFacetFilter(params map[string][]string) (FacetFilter, error) {
+	filter := make(FacetFilter)
+	for _, field := range FacetFields {
+		for _, value := range params[string(field)] {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if field == FacetSource && value != string(SourceOfficial) && value != string(SourceCommunity) {
+				return nil, fmt.Errorf("invalid source %q", value)
+			}
+			if !slices.Contains(filter[field], value) {
+				filter[field] = append(filter[field], value)
+			}
+		}
+	}
+	return filter, nil
+}
+
+// Has reports whether value is selected for the facet
+func (f FacetFilter) Has(field FacetField, value string) bool {
+	return slices.Contains(f[field], value)
+}
+
+// Matches reports whether the proverb has one of the selected values of
+// every filtered facet
+func (f FacetFilter) Matches(proverb Proverb) bool {
+	return f.matchesExcept(proverb, "")
+}
+
+// matchesExcept is Matches ignoring the filter on one facet
+func (f FacetFilter) matchesExcept(proverb Proverb, skip FacetField) bool {
+	for field, values := range f {
+		if field == skip || len(values) == 0 {
+			continue
+		}
+		if !slices.ContainsFunc(facetValues(proverb, field), func(v string) bool {
+			return slices.Contains(values, v)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterResults returns the results matching the filter, keeping their order
+func FilterResults(results []SearchResult, f FacetFilter) []SearchResult {
+	filtered := make([]SearchResult, 0, len(results))
+	for _, result := range results {
+		if f.Matches(result.Proverb) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// ComputeFacets counts facet values over the search results, the way
+// ComputeStats does for the whole collection. Each facet is counted over
+// the results matching the filters on every other facet, so selecting one
+// category still shows how many hits the others would add.
+func ComputeFacets(results []SearchResult, f FacetFilter) Facets {
+	facets := make(Facets)
+	for _, field := range FacetFields {
+		counts := make(map[string]int)
+		for _, result := range results {
+			if !f.matchesExcept(result.Proverb, field) {
+				continue
+			}
+			for _, value := range facetValues(result.Proverb, field) {
+				counts[value]++
+			}
+		}
+
+		values := make([]FacetValue, 0, len(counts))
+		for value, count := range counts {
+			values = append(values, FacetValue{Value: value, Count: count})
+		}
+		slices.SortFunc(values, func(a, b FacetValue) int {
+			if c := cmp.Compare(b.Count, a.Count); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Value, b.Value)
+		})
+		facets[field] = values
+	}
+	return facets
+}
+
+// facetValues returns the proverb's values for a facet
+func facetValues(proverb Proverb, field FacetField) []string {
+	switch field {
+	case FacetCategory:
+		if proverb.Category != "" {
+			return []string{string(proverb.Category)}
+		}
+	case FacetSource:
+		if proverb.Source != "" {
+			return []string{string(proverb.Source)}
+		}
+	case FacetAuthor:
+		if proverb.Author != "" {
+			return []string{proverb.Author}
+		}
+	case FacetTag:
+		return proverb.Tags
+	}
+	return nil
+}
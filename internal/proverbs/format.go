@@ -0,0 +1,66 @@
+package proverbs
+
+import (
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+// FormatExample formats an example as gofmt would. Fragments are formatted
+// in the layout CheckExample finds for them, each keeping its surrounding
+// blank lines. Examples whose front matter sets "gofmt: false" are returned
+// unchanged. It returns the first syntax error if the example does not
+// parse, as is the case for templates with actions outside string literals.
+func FormatExample(src string) (string, error) {
+	if strings.TrimSpace(src) == "" {
+		return src, nil
+	}
+	if meta, _, _ := ParseFrontMatter(src); !meta.Gofmt {
+		return src, nil
+	}
+
+	layout, errs := layoutExample("", "", src)
+	if errs != nil {
+		return "", fmt.Errorf("%d:%d: %s", errs[0].Line, errs[0].Column, errs[0].Message)
+	}
+
+	var out strings.Builder
+	for _, f := range layout.fragments {
+		formatted, err := format.Source([]byte(f.src))
+		if err != nil {
+			return "", err
+		}
+		out.Write(formatted)
+	}
+
+	// go/format treats // +build lines at the top of a fragment as a file
+	// header and can rewrite them into code that no longer parses
+	if _, errs := layoutExample("", "", out.String()); errs != nil {
+		return "", fmt.Errorf("formatting breaks the example; set \"gofmt: false\" in the front matter")
+	}
+	return out.String(), nil
+}
+
+// checkFormat reports an example gofmt would change, at the first line it
+// changes. Examples that do not parse are left to CheckExample.
+func checkFormat(id, file, src string) []ValidationError {
+	formatted, err := FormatExample(src)
+	if err != nil || formatted == src {
+		return nil
+	}
+	line := 1
+	for _, d := range DiffLines(strings.Split(src, "\n"), strings.Split(formatted, "\n")) {
+		if d.Op != DiffEqual {
+			break
+		}
+		line++
+	}
+	return []ValidationError{{
+		ProverbID: id,
+		Field:     "Example",
+		File:      file,
+		Line:      line,
+		Column:    1,
+		Message:   "example is not gofmt-formatted",
+	}}
+}
@@ -0,0 +1,169 @@
+package proverbs
+
+import (
+	"fmt"
+	"go/version"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// frontMatterFence opens and closes an example's front matter
+const frontMatterFence = "// ---"
+
+// defaultGoVersion is the language version examples that do not declare
+// one are built with
+const defaultGoVersion = "1.24"
+
+// ExampleMeta is the metadata an example declares in its front matter.
+// GoVersion is the minimum Go version it needs, e.g. "1.22", and Imports
+// the packages it uses without importing them. Output is an alternative to
+// an "// Output:" trailer. Gofmt is false for examples gofmt must not
+// touch, such as deliberately unformatted code.
+type ExampleMeta struct {
+	GoVersion string   `json:"go_version,omitempty"`
+	Imports   []string `json:"imports,omitempty"`
+	Runnable  bool     `json:"runnable"`
+	Output    string   `json:"output,omitempty"`
+	Gofmt     bool     `json:"gofmt"`
+}
+
+// ParseFrontMatter parses the front matter an example may start with, a
+// comment block of "key: value" lines between fences:
+//
+//	// ---
+//	// go: 1.22
+//	// imports: [iter, slices]
+//	// runnable: false
+//	// output: "1\n2"
+//	// gofmt: false
+//	// ---
+//
+// Values are written as in data files. It returns the metadata and the
+// number of lines the block spans, zero when there is none. Examples are
+// runnable and gofmt-formatted unless they say otherwise. Every problem is
+// reported, as DataErrors with lines relative to the example and no file.
+func ParseFrontMatter(src string) (ExampleMeta, int, error) {
+	meta := ExampleMeta{Runnable: true, Gofmt: true}
+	lines := strings.Split(src, "\n")
+	if strings.TrimRight(lines[0], " \t\r") != frontMatterFence {
+		return meta, 0, nil
+	}
+
+	var errs DataErrors
+	seen := make(map[string]bool)
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t\r")
+		if line == frontMatterFence {
+			if len(errs) > 0 {
+				return meta, i + 1, errs
+			}
+			return meta, i + 1, nil
+		}
+
+		fail := func(format string, args ...any) {
+			errs = append(errs, DataError{Line: i + 1, Message: fmt.Sprintf(format, args...)})
+		}
+		if !strings.HasPrefix(line, "//") {
+			fail("front matter line is not a comment")
+			continue
+		}
+		text := strings.TrimSpace(commentText(line))
+		if text == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			fail("expected \"key: value\", got %q", text)
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		if seen[key] {
+			fail("duplicate key %q", key)
+			continue
+		}
+		seen[key] = true
+
+		switch key {
+		case "go":
+			v, err := parseScalar(value)
+			switch {
+			case err != nil:
+				fail("go: %v", err)
+			case !version.IsValid("go" + v):
+				fail("go: invalid Go version %q (expected e.g. 1.22)", v)
+			default:
+				meta.GoVersion = v
+			}
+		case "imports":
+			imports, err := parseImportList(value)
+			if err != nil {
+				fail("imports: %v", err)
+				continue
+			}
+			meta.Imports = imports
+		case "runnable":
+			runnable, err := strconv.ParseBool(value)
+			if err != nil {
+				fail("runnable: expected true or false, got %q", value)
+				continue
+			}
+			meta.Runnable = runnable
+		case "gofmt":
+			gofmt, err := strconv.ParseBool(value)
+			if err != nil {
+				fail("gofmt: expected true or false, got %q", value)
+				continue
+			}
+			meta.Gofmt = gofmt
+		case "output":
+			output, err := parseScalar(value)
+			if err != nil {
+				fail("output: %v", err)
+				continue
+			}
+			meta.Output = output
+		default:
+			fail("unknown key %q (expected go, imports, runnable, output or gofmt)", key)
+		}
+	}
+	return ExampleMeta{Runnable: true, Gofmt: true}, 0, DataErrors{{Line: 1, Message: "front matter is not closed by \"// ---\""}}
+}
+
+// parseImportList decodes a flow list of import paths
+func parseImportList(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") {
+		return nil, fmt.Errorf("expected a list like [iter, slices]")
+	}
+	imports, err := parseFlowList(value)
+	if err != nil {
+		return nil, err
+	}
+	for _, imp := range imports {
+		if path.Clean(imp) != imp || strings.HasPrefix(imp, "/") || strings.ContainsAny(imp, " \t\"'`\\") {
+			return nil, fmt.Errorf("invalid import path %q", imp)
+		}
+	}
+	return imports, nil
+}
+
+// MinGoVersion returns the minimum Go version the proverb's example
+// declares, or "" if it declares none
+func (p Proverb) MinGoVersion() string {
+	if p.ExampleParts != nil {
+		return p.ExampleParts.Meta.GoVersion
+	}
+	meta, _, _ := ParseFrontMatter(p.Example)
+	return meta.GoVersion
+}
+
+// RunsOn reports whether an example needing Go version need can be built
+// with Go version have. An example that declares no version runs anywhere.
+func RunsOn(need, have string) bool {
+	return need == "" || version.Compare("go"+need, "go"+have) <= 0
+}
+
+// ValidGoVersion reports whether v is a Go version such as 1.22 or 1.22.3
+func ValidGoVersion(v string) bool {
+	return version.IsValid("go" + v)
+}
@@ -0,0 +1,91 @@
+package proverbs
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	defaults := ExampleMeta{Runnable: true, Gofmt: true}
+	tests := []struct {
+		name  string
+		src   string
+		meta  ExampleMeta
+		lines int
+	}{
+		{"none", "fmt.Println(1)", defaults, 0},
+		{"empty", "// ---\n// ---\nf()", defaults, 2},
+		{"go", "// ---\n// go: 1.22\n// ---\nf()", ExampleMeta{GoVersion: "1.22", Runnable: true, Gofmt: true}, 3},
+		{"quoted go", "// ---\n// go: \"1.22.3\"\n// ---", ExampleMeta{GoVersion: "1.22.3", Runnable: true, Gofmt: true}, 3},
+		{"imports", "// ---\n// imports: [iter, slices]\n// ---", ExampleMeta{Imports: []string{"iter", "slices"}, Runnable: true, Gofmt: true}, 3},
+		{"flags", "// ---\n// runnable: false\n// gofmt: false\n// ---", ExampleMeta{}, 4},
+		{"output", "// ---\n// output: \"1\\n2\"\n// ---", ExampleMeta{Output: "1\n2", Runnable: true, Gofmt: true}, 3},
+		{"keys ignore case and space", "// ---\n//   Go :  1.21\n//\n// ---", ExampleMeta{GoVersion: "1.21", Runnable: true, Gofmt: true}, 4},
+		{"trailing space on fences", "// --- \r\n// go: 1.23\n// ---\t\nf()", ExampleMeta{GoVersion: "1.23", Runnable: true, Gofmt: true}, 3},
+		{"fence must start the example", "f()\n// ---\n// go: 1.22\n// ---", defaults, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, lines, err := ParseFrontMatter(tt.src)
+			if err != nil {
+				t.Fatalf("ParseFrontMatter(%q) error: %v", tt.src, err)
+			}
+			if !reflect.DeepEqual(meta, tt.meta) || lines != tt.lines {
+				t.Errorf("ParseFrontMatter(%q) = %+v, %d, want %+v, %d", tt.src, meta, lines, tt.meta, tt.lines)
+			}
+		})
+	}
+}
+
+func TestParseFrontMatterErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want DataErrors
+	}{
+		{"unclosed", "// ---\n// go: 1.22\nf()", DataErrors{{Line: 1, Message: `front matter is not closed by "// ---"`}}},
+		{"not a comment", "// ---\ngo: 1.22\n// ---", DataErrors{{Line: 2, Message: "front matter line is not a comment"}}},
+		{"no colon", "// ---\n// go 1.22\n// ---", DataErrors{{Line: 2, Message: `expected "key: value", got "go 1.22"`}}},
+		{"bad version", "// ---\n// go: 1.x\n// ---", DataErrors{{Line: 2, Message: `go: invalid Go version "1.x" (expected e.g. 1.22)`}}},
+		{"bad bool", "// ---\n// runnable: maybe\n// ---", DataErrors{{Line: 2, Message: `runnable: expected true or false, got "maybe"`}}},
+		{"imports not a list", "// ---\n// imports: iter\n// ---", DataErrors{{Line: 2, Message: "imports: expected a list like [iter, slices]"}}},
+		{"bad import path", "// ---\n// imports: [iter/]\n// ---", DataErrors{{Line: 2, Message: `imports: invalid import path "iter/"`}}},
+		{"unknown key", "// ---\n// version: 1\n// ---", DataErrors{{Line: 2, Message: `unknown key "version" (expected go, imports, runnable, output or gofmt)`}}},
+		{"every problem", "// ---\n// go: 1.22\n// go: 1.23\n// gofmt: no\n// ---", DataErrors{
+			{Line: 3, Message: `duplicate key "go"`},
+			{Line: 4, Message: `gofmt: expected true or false, got "no"`},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseFrontMatter(tt.src)
+			var got DataErrors
+			if !errors.As(err, &got) {
+				t.Fatalf("ParseFrontMatter(%q) error = %v, want DataErrors", tt.src, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseFrontMatter(%q) errors = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunsOn(t *testing.T) {
+	tests := []struct {
+		need, have string
+		want       bool
+	}{
+		{"", "1.18", true},
+		{"1.22", "1.22", true},
+		{"1.22", "1.23", true},
+		{"1.22", "1.21", false},
+		{"1.21.3", "1.21", false},
+		{"1.9", "1.10", true},
+	}
+	for _, tt := range tests {
+		if got := RunsOn(tt.need, tt.have); got != tt.want {
+			t.Errorf("RunsOn(%q, %q) = %v, want %v", tt.need, tt.have, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package proverbs
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// maxSuggestions caps the alternative queries offered for a misspelling
+const maxSuggestions = 3
+
+// maxEdits returns how many edits a term of this length may be from an
+// indexed term and still match. Short terms must be spelled exactly.
+func maxEdits(term string) int {
+	switch n := len([]rune(term)); {
+	case n < 4:
+		return 0
+	case n < 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// editDistance returns the optimal string alignment distance between a
+// and b: insertions, deletions, substitutions and transpositions of
+// adjacent runes each count as one edit. It gives up and returns limit+1
+// as soon as the distance must exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+
+	// Three rows are enough for the transposition lookback
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	if prev[len(rb)] > limit {
+		return limit + 1
+	}
+	return prev[len(rb)]
+}
+
+// fuzzyCandidate is an indexed term close to a misspelled query term
+type fuzzyCandidate struct {
+	term     string
+	distance int
+	docs     int
+}
+
+// fuzzyCandidates returns the indexed prose terms within edit distance of
+// term, closest first and then by how many proverbs use them
+func (idx *SearchIndex) fuzzyCandidates(term string) []fuzzyCandidate {
+	limit := maxEdits(term)
+	if limit == 0 {
+		return nil
+	}
+
+	var candidates []fuzzyCandidate
+	for _, indexed := range idx.words {
+		if d := editDistance(term, indexed, limit); d <= limit {
+			candidates = append(candidates, fuzzyCandidate{term: indexed, distance: d, docs: len(idx.postings[indexed])})
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b fuzzyCandidate) int {
+		if c := cmp.Compare(a.distance, b.distance); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.docs, a.docs); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.term, b.term)
+	})
+	return candidates
+}
+
+// fuzzyExpand returns the indexed terms at the smallest edit distance from
+// term, so a typo matches what it was most likely meant to be
+func (idx *SearchIndex) fuzzyExpand(term string) []string {
+	candidates := idx.fuzzyCandidates(term)
+	var matches []string
+	for _, c := range candidates {
+		if c.distance != candidates[0].distance {
+			break
+		}
+		matches = append(matches, c.term)
+	}
+	return matches
+}
+
+// misspelled reports whether a query term matches no indexed term exactly
+// or as a prefix
+func (idx *SearchIndex) misspelled(term string) bool {
+	if _, ok := idx.postings[term]; ok {
+		return false
+	}
+	if len(term) >= minPrefixLen {
+		i, _ := slices.BinarySearch(idx.terms, term)
+		if i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term) {
+			return false
+		}
+	}
+	return true
+}
+
+// Suggest returns corrected versions of the query, best first, in which
+// each misspelled word is replaced by its closest indexed term. It returns
+// nil when every word is spelled like an indexed term.
+func (idx *SearchIndex) Suggest(sq *SearchQuery) []string {
+	type correction struct {
+		word       queryWord
+		candidates []fuzzyCandidate
+	}
+
+	var corrections []correction
+	for _, word := range sq.words {
+		if !idx.misspelled(word.term) {
+			continue
+		}
+		candidates := idx.fuzzyCandidates(word.term)
+		if len(candidates) > 0 {
+			corrections = append(corrections, correction{word: word, candidates: candidates})
+		}
+	}
+	if len(corrections) == 0 {
+		return nil
+	}
+
+	// The best suggestion corrects every word; when only one word is wrong
+	// its runner-up spellings are offered as well
+	alternatives := 1
+	if len(corrections) == 1 {
+		alternatives = min(maxSuggestions, len(corrections[0].candidates))
+	}
+
+	var suggestions []string
+	for alt := range alternatives {
+		var b strings.Builder
+		last := 0
+		for _, c := range corrections {
+			pick := 0
+			if len(corrections) == 1 {
+				pick = alt
+			}
+			b.WriteString(sq.Raw[last:c.word.start])
+			b.WriteString(c.candidates[pick].term)
+			last = c.word.end
+		}
+		b.WriteString(sq.Raw[last:])
+		suggestions = append(suggestions, b.String())
+	}
+	return suggestions
+}
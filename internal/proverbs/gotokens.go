@@ -0,0 +1,107 @@
+package proverbs
+
+import (
+	"go/scanner"
+	gotoken "go/token"
+	"strconv"
+	"strings"
+)
+
+// CodeHit locates a matched term in a proverb's example
+type CodeHit struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Term   string `json:"term"`
+}
+
+// goTokenize splits Go source into search terms with go/scanner.
+// Identifiers and keywords become lowercase terms, so a selector such as
+// sync.Once yields the adjacent terms "sync" and "once" and matches the
+// query phrase "sync.Once". Import paths are split into their segments,
+// making "golang.org/x/sync/errgroup" findable as errgroup. Comments and
+// other literals are skipped; the prose fields already cover them. The
+// scanner copes with fragments that are not valid Go files.
+func goTokenize(src string) []token {
+	fset := gotoken.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(src), func(gotoken.Position, string) {}, 0)
+
+	var tokens []token
+	inImport, importBlock := false, false
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == gotoken.EOF {
+			break
+		}
+		position := file.Position(pos)
+
+		switch {
+		case tok == gotoken.IMPORT:
+			inImport = true
+			tokens = appendTerm(tokens, "import", position)
+		case inImport && tok == gotoken.LPAREN:
+			importBlock = true
+		case inImport && tok == gotoken.RPAREN:
+			inImport, importBlock = false, false
+		case inImport && !importBlock && tok == gotoken.SEMICOLON:
+			inImport = false
+		case inImport && tok == gotoken.STRING:
+			path, err := strconv.Unquote(lit)
+			if err != nil {
+				continue
+			}
+			// Offsets are relative to the path inside the quotes
+			for _, t := range tokenize(path) {
+				tokens = append(tokens, token{
+					term:  t.term,
+					start: position.Offset + 1 + t.start,
+					end:   position.Offset + 1 + t.end,
+					line:  position.Line,
+					col:   position.Column + 1 + t.start,
+				})
+			}
+		case tok == gotoken.IDENT || tok.IsKeyword():
+			if tok.IsKeyword() {
+				lit = tok.String()
+			}
+			tokens = appendTerm(tokens, lit, position)
+		}
+	}
+	return tokens
+}
+
+// appendTerm adds an identifier or keyword unless it is a stop word
+func appendTerm(tokens []token, lit string, position gotoken.Position) []token {
+	term := strings.ToLower(lit)
+	if stopWords[term] {
+		return tokens
+	}
+	return append(tokens, token{
+		term:  term,
+		start: position.Offset,
+		end:   position.Offset + len(lit),
+		line:  position.Line,
+		col:   position.Column,
+	})
+}
+
+// codeHits returns where the given terms occur in the example, one hit per
+// term and line
+func codeHits(example string, terms map[string]bool) []CodeHit {
+	var hits []CodeHit
+	seen := make(map[CodeHit]bool)
+	for _, tok := range goTokenize(example) {
+		if !terms[tok.term] {
+			continue
+		}
+		key := CodeHit{Line: tok.line, Term: tok.term}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		hits = append(hits, CodeHit{Line: tok.line, Column: tok.col, Term: tok.term})
+	}
+	return hits
+}
@@ -0,0 +1,117 @@
+package proverbs
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// snippetLen is the approximate length in bytes of an explanation snippet
+const snippetLen = 160
+
+// snippetEllipsis marks text cut from either end of a snippet
+const snippetEllipsis = "…"
+
+// highlightFields are the prose fields match spans are reported for. Tags
+// are matched whole and code hits are reported by line instead.
+var highlightFields = []SearchField{FieldTitle, FieldText, FieldExplanation}
+
+// Span is the byte range [Start, End) of a matched term in a field
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Snippet is a short excerpt of a field with the spans of the matched
+// terms, relative to Text
+type Snippet struct {
+	Text  string `json:"text"`
+	Spans []Span `json:"spans,omitempty"`
+}
+
+// matchSpans returns the spans of the tokens in text whose term is one of
+// terms
+func matchSpans(text string, terms map[string]bool) []Span {
+	var spans []Span
+	for _, tok := range tokenize(text) {
+		if terms[tok.term] {
+			spans = append(spans, Span{Start: tok.start, End: tok.end})
+		}
+	}
+	return spans
+}
+
+// highlights returns the match spans of each prose field in the mask,
+// keyed by field name, or nil when nothing matched
+func highlights(proverb Proverb, terms map[string]bool, fields fieldMask) map[string][]Span {
+	var result map[string][]Span
+	text := fieldText(proverb)
+	for _, field := range highlightFields {
+		if !fields.has(field) {
+			continue
+		}
+		if spans := matchSpans(text[field], terms); len(spans) > 0 {
+			if result == nil {
+				result = make(map[string][]Span)
+			}
+			result[field.String()] = spans
+		}
+	}
+	return result
+}
+
+// makeSnippet cuts a window of about snippetLen bytes out of text, centred
+// on the first span, on word boundaries. Without spans it takes the start
+// of the text. Short texts are returned whole.
+func makeSnippet(text string, spans []Span) *Snippet {
+	if text == "" {
+		return nil
+	}
+	if len(text) <= snippetLen {
+		return &Snippet{Text: text, Spans: spans}
+	}
+
+	start := 0
+	if len(spans) > 0 {
+		first := spans[0]
+		start = max(0, first.Start-(snippetLen-(first.End-first.Start))/2)
+	}
+	end := min(len(text), start+snippetLen)
+	start = max(0, end-snippetLen)
+
+	// Move inwards to the nearest spaces so no word is cut in half
+	if start > 0 {
+		if i := strings.IndexByte(text[start:], ' '); i >= 0 && (len(spans) == 0 || start+i < spans[0].Start) {
+			start += i + 1
+		}
+		for start < len(text) && !utf8.RuneStart(text[start]) {
+			start++
+		}
+	}
+	if end < len(text) {
+		if i := strings.LastIndexByte(text[start:end], ' '); i > 0 {
+			end = start + i
+		}
+		for end > start && !utf8.RuneStart(text[end]) {
+			end--
+		}
+	}
+
+	var b strings.Builder
+	offset := -start
+	if start > 0 {
+		b.WriteString(snippetEllipsis)
+		offset += len(snippetEllipsis)
+	}
+	b.WriteString(text[start:end])
+	if end < len(text) {
+		b.WriteString(snippetEllipsis)
+	}
+
+	snippet := &Snippet{Text: b.String()}
+	for _, span := range spans {
+		if span.Start >= start && span.End <= end {
+			snippet.Spans = append(snippet.Spans, Span{Start: span.Start + offset, End: span.End + offset})
+		}
+	}
+	return snippet
+}
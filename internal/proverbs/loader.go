@@ -1,10 +1,15 @@
 package proverbs
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"math/rand"
 	"os"
+	"path"
+	"slices"
 	"strings"
 	"time"
 )
@@ -16,14 +21,37 @@ func LoadAllProverbs() *ProverbCollection {
 		if err := InitExampleLoader(); err != nil {
 			// Log error but continue with empty examples
 			fmt.Printf("Warning: Failed to load examples: %v\n", err)
+		} else {
+			for _, de := range globalExampleLoader.Errors() {
+				fmt.Printf("Warning: %v\n", de)
+			}
 		}
 	}
 	
-	return &ProverbCollection{
+	// Prefer the data files; fall back to the compiled-in set if any of them
+	// fail to parse so a bad edit never takes the site down
+	collection, err := loadDataFiles()
+	if err == nil {
+		collection.searchIndex()
+		return collection
+	}
+	var dataErrs DataErrors
+	if errors.As(err, &dataErrs) {
+		for _, de := range dataErrs {
+			fmt.Printf("Warning: %v\n", de)
+		}
+		err = fmt.Errorf("%d invalid data files", len(dataErrs))
+	}
+	fmt.Printf("Warning: Failed to load proverb data files, using embedded set: %v\n", err)
+
+	collection = &ProverbCollection{
 		Official:  GetOfficialProverbs(),
 		Community: GetCommunityProverbs(),
 		UpdatedAt: time.Now(),
 	}
+	collection.setIDs()
+	collection.searchIndex()
+	return collection
 }
 
 
@@ -39,6 +67,7 @@ func LoadFromFile(filename string) (*ProverbCollection, error) {
 	if err := json.Unmarshal(data, &collection); err != nil {
 		return nil, fmt.Errorf("unmarshaling JSON: %w", err)
 	}
+	collection.setIDs()
 	
 	return &collection, nil
 }
@@ -59,16 +88,25 @@ func (pc *ProverbCollection) SaveToFile(filename string) error {
 
 // GetStats returns statistics about the proverb collection
 func (pc *ProverbCollection) GetStats() ProverbStats {
+	return ComputeStats(pc.GetAll())
+}
+
+// ComputeStats returns statistics about the given proverbs
+func ComputeStats(proverbs []Proverb) ProverbStats {
 	stats := ProverbStats{
-		Total:     len(pc.Official) + len(pc.Community),
-		Official:  len(pc.Official),
-		Community: len(pc.Community),
+		Total:      len(proverbs),
 		Categories: make(map[Category]int),
 		Tags:       make(map[string]int),
 	}
 	
-	// Count by category and tags
-	for _, proverb := range pc.GetAll() {
+	// Count by source, category and tags
+	for _, proverb := range proverbs {
+		switch proverb.Source {
+		case SourceOfficial:
+			stats.Official++
+		case SourceCommunity:
+			stats.Community++
+		}
 		stats.Categories[proverb.Category]++
 		for _, tag := range proverb.Tags {
 			stats.Tags[tag]++
@@ -87,29 +125,18 @@ type ProverbStats struct {
 	Tags       map[string]int     `json:"tags"`
 }
 
-// SearchProverbs searches for proverbs containing the given text
+// SearchProverbs returns the proverbs matching the query, best match first.
+// Malformed queries match nothing.
 func (pc *ProverbCollection) SearchProverbs(query string) []Proverb {
-	var results []Proverb
-	queryLower := strings.ToLower(query)
-	
-	for _, proverb := range pc.GetAll() {
-		if strings.Contains(strings.ToLower(proverb.Title), queryLower) ||
-			strings.Contains(strings.ToLower(proverb.Text), queryLower) ||
-			strings.Contains(strings.ToLower(proverb.Explanation), queryLower) {
-			results = append(results, proverb)
-			continue
-		}
-		
-		// Search in tags
-		for _, tag := range proverb.Tags {
-			if strings.Contains(strings.ToLower(tag), queryLower) {
-				results = append(results, proverb)
-				break
-			}
-		}
+	response, err := pc.Search(query)
+	if err != nil {
+		return nil
 	}
-	
-	return results
+	proverbs := make([]Proverb, len(response.Results))
+	for i, result := range response.Results {
+		proverbs[i] = result.Proverb
+	}
+	return proverbs
 }
 
 // GetRandomProverb returns a random proverb from the collection
@@ -123,8 +150,37 @@ func (pc *ProverbCollection) GetRandomProverb() Proverb {
 	return all[index]
 }
 
-// ValidateCollection validates all proverbs in the collection
+// Validator is implemented by stores that validate their proverbs
+// themselves
+type Validator interface {
+	ValidateCollection() []ValidationError
+}
+
+// Validate validates every proverb in the store as ValidateCollection does
+func Validate(store Store) ([]ValidationError, error) {
+	if validator, ok := store.(Validator); ok {
+		return validator.ValidateCollection(), nil
+	}
+	all, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	var errors []ValidationError
+	for _, proverb := range all {
+		errors = append(errors, validateProverb(proverb.ID, proverb)...)
+	}
+	errors = append(errors, validateExampleFiles(nil, all)...)
+	sortValidationErrors(errors)
+	return errors, nil
+}
+
+// ValidateCollection validates all proverbs in the collection, including
+// compile, vet and gofmt checks of their examples and the checks of
+// ValidateExamples, ordered by proverb ID and position
 func (pc *ProverbCollection) ValidateCollection() []ValidationError {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
 	var errors []ValidationError
 	
 	// Validate official proverbs
@@ -136,14 +192,108 @@ func (pc *ProverbCollection) ValidateCollection() []ValidationError {
 	for id, proverb := range pc.Community {
 		errors = append(errors, validateProverb(id, proverb)...)
 	}
-	
+
+	errors = append(errors, validateExampleFiles(pc.dataFS, pc.proverbs())...)
+	sortValidationErrors(errors)
+	return errors
+}
+
+// ValidateExamples reports empty examples and, when the collection was
+// loaded from a data directory, proverbs without an example file and
+// example files without a proverb
+func (pc *ProverbCollection) ValidateExamples() []ValidationError {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	errors := validateExampleFiles(pc.dataFS, pc.proverbs())
+	sortValidationErrors(errors)
 	return errors
 }
 
+// proverbs returns every proverb in the collection. The caller must hold
+// pc.mu.
+func (pc *ProverbCollection) proverbs() []Proverb {
+	all := make([]Proverb, 0, len(pc.Official)+len(pc.Community))
+	for _, proverb := range pc.Official {
+		all = append(all, proverb)
+	}
+	for _, proverb := range pc.Community {
+		all = append(all, proverb)
+	}
+	return all
+}
+
+// validateExampleFiles compares the proverbs with the files of the data
+// directory fsys they were loaded from. A proverb with a data file needs an
+// example file next to it; other proverbs, such as those added through a
+// store, only need a non-empty example. When fsys is nil or cannot be read
+// only empty examples are reported.
+func validateExampleFiles(fsys fs.FS, proverbs []Proverb) []ValidationError {
+	var sources map[string]string
+	var dataFiles map[string]bool
+	if fsys != nil {
+		sources, dataFiles, _ = readDataDir(fsys)
+	}
+
+	var errors []ValidationError
+	seen := make(map[string]bool)
+	for _, proverb := range proverbs {
+		file := ExampleFile(proverb)
+		seen[file] = true
+		src, exists := sources[file]
+		hasDataFile := dataFiles[strings.TrimSuffix(file, ExampleExt)+DataFileExt]
+
+		message := ""
+		switch {
+		case hasDataFile && !exists:
+			message = "no example file"
+		case strings.TrimSpace(proverb.Example) != "":
+		case exists && strings.TrimSpace(src) == "":
+			message = "example file is empty"
+		case exists:
+			message = "example is empty after executing its template"
+		default:
+			message = "example is empty"
+		}
+		if message != "" {
+			errors = append(errors, ValidationError{ProverbID: proverb.ID, Field: "Example", File: file, Message: message})
+		}
+	}
+
+	for file := range sources {
+		if !seen[file] {
+			id := strings.TrimSuffix(path.Base(file), ExampleExt)
+			errors = append(errors, ValidationError{ProverbID: id, Field: "Example", File: file, Message: "example file has no proverb"})
+		}
+	}
+	return errors
+}
+
+// sortValidationErrors orders errors by proverb ID and position
+func sortValidationErrors(errors []ValidationError) {
+	slices.SortStableFunc(errors, func(a, b ValidationError) int {
+		if c := cmp.Compare(a.ProverbID, b.ProverbID); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.Line, b.Line); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Column, b.Column)
+	})
+}
+
 // validateProverb validates a single proverb
 func validateProverb(id string, proverb Proverb) []ValidationError {
 	var errors []ValidationError
 	
+	if proverb.ID != id {
+		errors = append(errors, ValidationError{
+			ProverbID: id,
+			Field:     "ID",
+			Message:   fmt.Sprintf("ID %q does not match key %q", proverb.ID, id),
+		})
+	}
+
 	// Validate required fields
 	if proverb.Title == "" {
 		errors = append(errors, ValidationError{
@@ -186,18 +336,38 @@ func validateProverb(id string, proverb Proverb) []ValidationError {
 			Message:   fmt.Sprintf("invalid source: %s", proverb.Source),
 		})
 	}
+
+	// Check that the example compiles, passes the vet checks and is
+	// formatted, reporting positions in its template
+	exampleErrors := CheckExample(id, ExampleFile(proverb), proverb.Example)
+	exampleErrors = append(exampleErrors, VetExample(id, ExampleFile(proverb), proverb.Example)...)
+	exampleErrors = append(exampleErrors, checkFormat(id, ExampleFile(proverb), proverb.Example)...)
+	for i := range exampleErrors {
+		exampleErrors[i].Line = proverb.templateLine(exampleErrors[i].Line)
+	}
+	errors = append(errors, exampleErrors...)
 	
 	return errors
 }
 
-// ValidationError represents a validation error for a proverb
+// ValidationError represents a validation error for a proverb. Errors in
+// a proverb's example also carry the file and position they refer to.
 type ValidationError struct {
 	ProverbID string `json:"proverb_id"`
 	Field     string `json:"field"`
 	Message   string `json:"message"`
+	File      string `json:"file,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Column    int    `json:"column,omitempty"`
 }
 
 func (ve ValidationError) Error() string {
+	if ve.File != "" && ve.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: proverb %s: %s - %s", ve.File, ve.Line, ve.Column, ve.ProverbID, ve.Field, ve.Message)
+	}
+	if ve.File != "" {
+		return fmt.Sprintf("%s: proverb %s: %s - %s", ve.File, ve.ProverbID, ve.Field, ve.Message)
+	}
 	return fmt.Sprintf("proverb %s: %s - %s", ve.ProverbID, ve.Field, ve.Message)
 }
 
@@ -0,0 +1,186 @@
+package proverbs
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// outputHeader matches the comment line that starts an example's expected
+// output, the same way go test recognizes it in example functions
+var outputHeader = regexp.MustCompile(`(?i)^(unordered )?output:`)
+
+// ExpectedOutput returns the output an example declares in its trailing
+// comment block, introduced by "// Output:" or "// Unordered output:" as in
+// go test example functions, or else in its front matter. Unordered output
+// may be printed in any line order. ok is false when the example declares
+// no output.
+func ExpectedOutput(example string) (output string, unordered, ok bool) {
+	lines := strings.Split(strings.TrimRight(example, " \t\r\n"), "\n")
+	i := outputStart(lines)
+	if i < 0 {
+		meta, _, _ := ParseFrontMatter(example)
+		return strings.TrimSpace(meta.Output), false, meta.Output != ""
+	}
+	text := commentText(lines[i])
+	m := outputHeader.FindStringSubmatch(text)
+	want := []string{strings.TrimSpace(text[len(m[0]):])}
+	for _, line := range lines[i+1:] {
+		want = append(want, commentText(line))
+	}
+	return strings.TrimSpace(strings.Join(want, "\n")), m[1] != "", true
+}
+
+// outputStart returns the index of the line starting the output trailer in
+// the last comment block of lines, or -1 if there is none. Front matter is
+// never part of the trailer.
+func outputStart(lines []string) int {
+	_, skip, _ := ParseFrontMatter(strings.Join(lines, "\n"))
+	start := len(lines)
+	for start > skip && strings.HasPrefix(strings.TrimSpace(lines[start-1]), "//") {
+		start--
+	}
+	for i := start; i < len(lines); i++ {
+		if outputHeader.MatchString(commentText(lines[i])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// commentText strips the comment marker and one following space
+func commentText(line string) string {
+	text := strings.TrimPrefix(strings.TrimSpace(line), "//")
+	return strings.TrimPrefix(text, " ")
+}
+
+// OutputMatches compares a program's output with the expected output,
+// ignoring leading and trailing space and, when unordered, line order
+func OutputMatches(got, want string, unordered bool) bool {
+	got, want = normalizeOutput(got), normalizeOutput(want)
+	if !unordered {
+		return got == want
+	}
+	gotLines, wantLines := strings.Split(got, "\n"), strings.Split(want, "\n")
+	slices.Sort(gotLines)
+	slices.Sort(wantLines)
+	return slices.Equal(gotLines, wantLines)
+}
+
+// normalizeOutput trims surrounding space and Windows line endings
+func normalizeOutput(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
+
+// Verification is the result of checking an example's output against the
+// output it declares
+type Verification struct {
+	ID        string     `json:"id"`
+	Expected  string     `json:"expected"`
+	Actual    string     `json:"actual"`
+	Unordered bool       `json:"unordered,omitempty"`
+	Passed    bool       `json:"passed"`
+	Diff      string     `json:"diff,omitempty"`
+	Result    *RunResult `json:"result"`
+}
+
+// VerifyExample runs the proverb's example and compares what it prints with
+// the output it declares. It returns nil when the example declares no
+// output or is not runnable. An example that fails to build, exits with an
+// error or times out does not pass.
+func VerifyExample(ctx context.Context, proverb Proverb, opts RunOptions) (*Verification, error) {
+	want, unordered, ok := ExpectedOutput(proverb.Example)
+	if meta, _, _ := ParseFrontMatter(proverb.Example); !ok || !meta.Runnable {
+		return nil, nil
+	}
+
+	result, err := RunExample(ctx, proverb, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Verification{
+		ID:        proverb.ID,
+		Expected:  want,
+		Actual:    normalizeOutput(result.Stdout),
+		Unordered: unordered,
+		Passed:    result.Passed != nil && *result.Passed,
+		Result:    result,
+	}
+	if !v.Passed {
+		v.Diff = DiffOutput(v.Expected, v.Actual)
+	}
+	return v, nil
+}
+
+// DiffOp is the kind of change a DiffLine makes
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffRemove
+	DiffAdd
+)
+
+// DiffLine is one line of a line diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffOutput returns a line diff turning want into got, with removed lines
+// prefixed by "- ", added lines by "+ " and common lines by two spaces
+func DiffOutput(want, got string) string {
+	var d strings.Builder
+	for _, line := range DiffLines(strings.Split(want, "\n"), strings.Split(got, "\n")) {
+		switch line.Op {
+		case DiffRemove:
+			d.WriteString("- ")
+		case DiffAdd:
+			d.WriteString("+ ")
+		default:
+			d.WriteString("  ")
+		}
+		d.WriteString(line.Text + "\n")
+	}
+	return d.String()
+}
+
+// DiffLines returns the shortest line diff turning a into b, listing the
+// removals of each change before its additions
+func DiffLines(a, b []string) []DiffLine {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := make([]DiffLine, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff = append(diff, DiffLine{DiffEqual, a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			diff = append(diff, DiffLine{DiffRemove, a[i]})
+			i++
+		default:
+			diff = append(diff, DiffLine{DiffAdd, b[j]})
+			j++
+		}
+	}
+	return diff
+}
@@ -0,0 +1,78 @@
+package proverbs
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExpectedOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		example   string
+		output    string
+		unordered bool
+		ok        bool
+	}{
+		{"none", "fmt.Println(1)", "", false, false},
+		{"trailer", "fmt.Println(1)\n// Output: 1", "1", false, true},
+		{"multiline", "fmt.Println(1)\nfmt.Println(2)\n// Output:\n// 1\n// 2\n", "1\n2", false, true},
+		{"case insensitive", "fmt.Println(1)\n// output: 1", "1", false, true},
+		{"unordered", "go f()\n// Unordered output:\n// b\n// a", "b\na", true, true},
+		{"indented", "func main() {\n\tfmt.Println(1)\n\t// Output: 1\n}", "", false, false},
+		{"not last block", "// Output: 1\nfmt.Println(1)", "", false, false},
+		{"comments before header", "fmt.Println(1)\n// prints one\n// Output: 1", "1", false, true},
+		{"trims surrounding space", "f()\n// Output:\n//   a\n// b", "a\nb", false, true},
+		{"trailing blank lines", "f()\n// Output: x\n\n\n", "x", false, true},
+		{"front matter", "// ---\n// output: \"1\\n2\"\n// ---\nf()", "1\n2", false, true},
+		{"trailer wins over front matter", "// ---\n// output: 1\n// ---\nf()\n// Output: 2", "2", false, true},
+		{"front matter is not a trailer", "// ---\n// output: 1\n// ---\n// a comment", "1", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, unordered, ok := ExpectedOutput(tt.example)
+			if output != tt.output || unordered != tt.unordered || ok != tt.ok {
+				t.Errorf("ExpectedOutput(%q) = %q, %v, %v, want %q, %v, %v",
+					tt.example, output, unordered, ok, tt.output, tt.unordered, tt.ok)
+			}
+		})
+	}
+}
+
+func TestOutputMatches(t *testing.T) {
+	tests := []struct {
+		got, want string
+		unordered bool
+		match     bool
+	}{
+		{"1\n2\n", "1\n2", false, true},
+		{"  1\r\n2\r\n", "1\n2", false, true},
+		{"2\n1\n", "1\n2", false, false},
+		{"2\n1\n", "1\n2", true, true},
+		{"1\n1\n", "1\n2", true, false},
+		{"1\n2\n3\n", "1\n2", true, false},
+		{"", "", false, true},
+	}
+	for _, tt := range tests {
+		if got := OutputMatches(tt.got, tt.want, tt.unordered); got != tt.match {
+			t.Errorf("OutputMatches(%q, %q, %v) = %v, want %v", tt.got, tt.want, tt.unordered, got, tt.match)
+		}
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want []DiffLine
+	}{
+		{nil, nil, []DiffLine{}},
+		{[]string{"a"}, []string{"a"}, []DiffLine{{DiffEqual, "a"}}},
+		{[]string{"a", "b"}, []string{"a", "c"}, []DiffLine{{DiffEqual, "a"}, {DiffRemove, "b"}, {DiffAdd, "c"}}},
+		{[]string{"a"}, []string{"x", "a", "y"}, []DiffLine{{DiffAdd, "x"}, {DiffEqual, "a"}, {DiffAdd, "y"}}},
+		{[]string{"a", "b", "c"}, []string{"c"}, []DiffLine{{DiffRemove, "a"}, {DiffRemove, "b"}, {DiffEqual, "c"}}},
+	}
+	for _, tt := range tests {
+		if got := DiffLines(tt.a, tt.b); !slices.Equal(got, tt.want) {
+			t.Errorf("DiffLines(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
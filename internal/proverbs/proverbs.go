@@ -3,20 +3,29 @@ package proverbs
 import (
 	"encoding/json"
 	"fmt"
+	"io/fs"
+	"sync"
 	"time"
 )
 
 // Proverb represents a single Go proverb with metadata
 type Proverb struct {
-	Title       string    `json:"title"`
-	Text        string    `json:"text"`
-	Author      string    `json:"author"`
-	Category    Category  `json:"category"`
-	Example     string    `json:"example,omitempty"`
-	Explanation string    `json:"explanation,omitempty"`
-	Tags        []string  `json:"tags,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	Source      Source    `json:"source"`
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	Text         string    `json:"text"`
+	Author       string    `json:"author"`
+	Category     Category  `json:"category"`
+	Example      string    `json:"example,omitempty"`
+	ExampleParts *Example  `json:"example_parts,omitempty"`
+	Explanation  string    `json:"explanation,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+	CreatedAt    time.Time `json:"created_at,omitzero"`
+	Source       Source    `json:"source"`
+	Popularity   int       `json:"popularity"`
+
+	// templateLines maps the lines of Example to the lines of the
+	// template it was executed from; see templateLines
+	templateLines []int
 }
 
 // Category represents the type of proverb
@@ -43,15 +52,28 @@ const (
 	SourceCommunity Source = "community"
 )
 
-// ProverbCollection holds all proverbs organized by source
+// ProverbCollection holds all proverbs organized by source. It is the
+// in-memory Store implementation.
 type ProverbCollection struct {
 	Official  map[string]Proverb `json:"official"`
 	Community map[string]Proverb `json:"community"`
 	UpdatedAt time.Time          `json:"updated_at"`
+
+	// dataFS is the data directory the collection was loaded from, whose
+	// files ValidateExamples compares it with, or nil
+	dataFS fs.FS
+
+	mu sync.RWMutex
+
+	indexMu sync.Mutex
+	index   *SearchIndex
 }
 
-// GetAll returns all proverbs from both sources
+// GetAll returns all proverbs from both sources, ordered by ID
 func (pc *ProverbCollection) GetAll() []Proverb {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
 	all := make([]Proverb, 0, len(pc.Official)+len(pc.Community))
 	
 	// Add official proverbs
@@ -64,35 +86,20 @@ func (pc *ProverbCollection) GetAll() []Proverb {
 		all = append(all, proverb)
 	}
 	
+	SortProverbs(all, DefaultSort)
 	return all
 }
 
 // GetByCategory returns proverbs filtered by category
 func (pc *ProverbCollection) GetByCategory(category Category) []Proverb {
-	var result []Proverb
-	for _, proverb := range pc.GetAll() {
-		if proverb.Category == category {
-			result = append(result, proverb)
-		}
-	}
-	return result
+	return filterProverbs(pc.GetAll(), Query{Category: category})
 }
 
 // GetBySource returns proverbs filtered by source
 func (pc *ProverbCollection) GetBySource(source Source) []Proverb {
 	switch source {
-	case SourceOfficial:
-		result := make([]Proverb, 0, len(pc.Official))
-		for _, proverb := range pc.Official {
-			result = append(result, proverb)
-		}
-		return result
-	case SourceCommunity:
-		result := make([]Proverb, 0, len(pc.Community))
-		for _, proverb := range pc.Community {
-			result = append(result, proverb)
-		}
-		return result
+	case SourceOfficial, SourceCommunity:
+		return filterProverbs(pc.GetAll(), Query{Source: source})
 	default:
 		return pc.GetAll()
 	}
@@ -100,20 +107,15 @@ func (pc *ProverbCollection) GetBySource(source Source) []Proverb {
 
 // GetByTag returns all proverbs that contain a specific tag
 func (pc *ProverbCollection) GetByTag(tag string) []Proverb {
-	var result []Proverb
-	for _, proverb := range pc.GetAll() {
-		for _, proverbTag := range proverb.Tags {
-			if proverbTag == tag {
-				result = append(result, proverb)
-				break
-			}
-		}
-	}
-	return result
+	return filterProverbs(pc.GetAll(), Query{Tag: tag})
 }
 
-// GetByID returns a proverb by its ID
+// GetByID returns a proverb by its ID. The source maps are keyed by ID, so
+// this is a constant-time lookup.
 func (pc *ProverbCollection) GetByID(id string) *Proverb {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
 	if proverb, exists := pc.Official[id]; exists {
 		return &proverb
 	}
@@ -123,8 +125,40 @@ func (pc *ProverbCollection) GetByID(id string) *Proverb {
 	return nil
 }
 
+// RecordView counts a view of the proverb towards its popularity
+func (pc *ProverbCollection) RecordView(id string) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if proverb, exists := pc.Official[id]; exists {
+		proverb.Popularity++
+		pc.Official[id] = proverb
+	} else if proverb, exists := pc.Community[id]; exists {
+		proverb.Popularity++
+		pc.Community[id] = proverb
+	}
+}
+
+// setIDs fills in each proverb's ID from its map key and splits its
+// example into sections
+func (pc *ProverbCollection) setIDs() {
+	for id, proverb := range pc.Official {
+		proverb.ID = id
+		proverb.ExampleParts = ParseExample(proverb.Example)
+		pc.Official[id] = proverb
+	}
+	for id, proverb := range pc.Community {
+		proverb.ID = id
+		proverb.ExampleParts = ParseExample(proverb.Example)
+		pc.Community[id] = proverb
+	}
+}
+
 // ToJSON converts the collection to JSON
 func (pc *ProverbCollection) ToJSON() ([]byte, error) {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
 	return json.MarshalIndent(pc, "", "  ")
 }
 
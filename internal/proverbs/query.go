@@ -0,0 +1,556 @@
+package proverbs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// QueryError describes a malformed search query
+type QueryError struct {
+	Query   string `json:"query"`
+	Offset  int    `json:"offset"`
+	Message string `json:"message"`
+}
+
+func (qe *QueryError) Error() string {
+	return fmt.Sprintf("invalid query at offset %d: %s", qe.Offset, qe.Message)
+}
+
+// queryFields are the field filters understood by ParseQuery
+var queryFields = []string{"author", "category", "id", "in", "source", "tag"}
+
+// SearchQuery is a parsed search query. The syntax is:
+//
+//	word            proverbs containing the word (or a word it prefixes)
+//	"a phrase"      proverbs containing the words next to each other
+//	field:value     category, tag, source, author or id filter; the value
+//	                may be quoted, e.g. author:"Rob Pike"
+//	in:field        match words and phrases only in title, text,
+//	                explanation, tags or code; repeat to allow several
+//	-term, NOT term proverbs not matching term
+//	a OR b          proverbs matching either side
+//	( ... )         grouping
+//
+// Terms separated by spaces must all match. Category, tag and source
+// filters match exactly like GetByCategory, GetByTag and GetBySource.
+// Words match in every field, including example code, unless in: is given;
+// it applies to the whole query wherever it appears.
+type SearchQuery struct {
+	Raw    string
+	root   queryNode
+	terms  []string
+	words  []queryWord
+	fields fieldMask
+}
+
+// queryWord is a free-text term and its byte offsets in the raw query
+type queryWord struct {
+	term       string
+	start, end int
+}
+
+// Terms returns the positive free-text terms used to rank results
+func (sq *SearchQuery) Terms() []string {
+	return sq.terms
+}
+
+// ParseQuery parses a search query, returning a *QueryError when it is
+// malformed
+func ParseQuery(query string) (*SearchQuery, error) {
+	p := &queryParser{query: query}
+	if err := p.lex(); err != nil {
+		return nil, err
+	}
+
+	sq := &SearchQuery{Raw: query, fields: allFields}
+	if len(p.tokens) == 0 {
+		return sq, nil
+	}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		return nil, p.errorAt(tok.offset, "unexpected %q", tok.text)
+	}
+
+	sq.root = root
+	sq.words = p.words
+	if p.fields != 0 {
+		sq.fields = p.fields
+	}
+	collectTerms(root, false, &sq.terms)
+	return sq, nil
+}
+
+// Run evaluates a parsed query against the index, best match first. When
+// nothing matches, or one of the words matches no proverb, it suggests
+// corrections for misspelled words.
+func (idx *SearchIndex) Run(sq *SearchQuery) *SearchResponse {
+	response := &SearchResponse{Results: []SearchResult{}}
+	if sq.root == nil {
+		return response
+	}
+
+	matched := sq.root.eval(idx, sq.fields)
+	scores := make(map[int]float64)
+	matchTerms := make(map[string]bool)
+	for _, term := range sq.terms {
+		for _, expanded := range idx.expand(term) {
+			weight := 1.0
+			if expanded != term {
+				weight = prefixWeight
+			}
+			idx.scoreTerm(expanded, weight, sq.fields, scores)
+			matchTerms[expanded] = true
+		}
+	}
+
+	for doc, ok := range matched {
+		if !ok {
+			continue
+		}
+		result := SearchResult{
+			Proverb:    idx.docs[doc],
+			Score:      roundScore(scores[doc]),
+			Highlights: highlights(idx.docs[doc], matchTerms, sq.fields),
+		}
+		result.Snippet = makeSnippet(result.Explanation, result.Highlights[FieldExplanation.String()])
+		if sq.fields.has(FieldCode) {
+			result.CodeHits = codeHits(result.Example, matchTerms)
+		}
+		response.Results = append(response.Results, result)
+	}
+	SortResults(response.Results, Sort{Field: SortByRelevance, Order: OrderDesc})
+	if len(response.Results) == 0 || idx.unmatchedWord(sq) {
+		response.Suggestions = idx.Suggest(sq)
+	}
+	return response
+}
+
+// unmatchedWord reports whether any word of the query matches no proverb
+// in the searched fields
+func (idx *SearchIndex) unmatchedWord(sq *SearchQuery) bool {
+	for _, word := range sq.words {
+		if !slices.Contains(termNode{terms: []string{word.term}}.eval(idx, sq.fields), true) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryNode is a node of a parsed query. eval returns, for every document
+// in the index, whether it matches; free text only matches in the masked
+// fields.
+type queryNode interface {
+	eval(idx *SearchIndex, fields fieldMask) []bool
+}
+
+type andNode []queryNode
+
+func (n andNode) eval(idx *SearchIndex, fields fieldMask) []bool {
+	result := n[0].eval(idx, fields)
+	for _, child := range n[1:] {
+		for doc, ok := range child.eval(idx, fields) {
+			result[doc] = result[doc] && ok
+		}
+	}
+	return result
+}
+
+type orNode []queryNode
+
+func (n orNode) eval(idx *SearchIndex, fields fieldMask) []bool {
+	result := n[0].eval(idx, fields)
+	for _, child := range n[1:] {
+		for doc, ok := range child.eval(idx, fields) {
+			result[doc] = result[doc] || ok
+		}
+	}
+	return result
+}
+
+type notNode struct {
+	child queryNode
+}
+
+func (n notNode) eval(idx *SearchIndex, fields fieldMask) []bool {
+	result := n.child.eval(idx, fields)
+	for doc := range result {
+		result[doc] = !result[doc]
+	}
+	return result
+}
+
+// termNode matches free text. A single term also matches the indexed terms
+// it prefixes; several terms must appear consecutively as a phrase.
+type termNode struct {
+	terms []string
+}
+
+func (n termNode) eval(idx *SearchIndex, fields fieldMask) []bool {
+	result := make([]bool, len(idx.docs))
+	if len(n.terms) == 1 {
+		for _, term := range idx.expand(n.terms[0]) {
+			for _, p := range idx.postings[term] {
+				for field := range numSearchFields {
+					if p.freq[field] > 0 && fields.has(field) {
+						result[p.doc] = true
+						break
+					}
+				}
+			}
+		}
+		return result
+	}
+
+	// Candidates contain every term; then check they are adjacent
+	counts := make(map[int]int)
+	for _, term := range n.terms {
+		for _, p := range idx.postings[term] {
+			counts[p.doc]++
+		}
+	}
+	for doc, count := range counts {
+		if count == len(n.terms) && containsPhrase(idx.docs[doc], n.terms, fields) {
+			result[doc] = true
+		}
+	}
+	return result
+}
+
+// fieldNode filters on a proverb field
+type fieldNode struct {
+	field string
+	value string
+}
+
+func (n fieldNode) eval(idx *SearchIndex, _ fieldMask) []bool {
+	result := make([]bool, len(idx.docs))
+	for doc, proverb := range idx.docs {
+		result[doc] = n.matches(proverb)
+	}
+	return result
+}
+
+func (n fieldNode) matches(proverb Proverb) bool {
+	switch n.field {
+	case "category":
+		return Query{Category: Category(strings.ToLower(n.value))}.Matches(proverb)
+	case "source":
+		return Query{Source: Source(strings.ToLower(n.value))}.Matches(proverb)
+	case "tag":
+		return Query{Tag: n.value}.Matches(proverb)
+	case "author":
+		return strings.EqualFold(proverb.Author, n.value)
+	case "id":
+		return proverb.ID == n.value
+	}
+	return false
+}
+
+// containsPhrase reports whether any masked field holds the terms in order
+func containsPhrase(proverb Proverb, terms []string, fields fieldMask) bool {
+	for field, tokens := range fieldTokens(proverb) {
+		if !fields.has(SearchField(field)) {
+			continue
+		}
+		for i := 0; i+len(terms) <= len(tokens); i++ {
+			match := true
+			for j, term := range terms {
+				if tokens[i+j].term != term {
+					match = false
+					break
+				}
+			}
+			if match {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parseSearchField looks up an indexed field by name
+func parseSearchField(name string) (SearchField, bool) {
+	for field, fieldName := range fieldNames {
+		if strings.EqualFold(name, fieldName) {
+			return SearchField(field), true
+		}
+	}
+	return 0, false
+}
+
+// collectTerms gathers the free-text terms that are not negated
+func collectTerms(node queryNode, negated bool, terms *[]string) {
+	switch n := node.(type) {
+	case andNode:
+		for _, child := range n {
+			collectTerms(child, negated, terms)
+		}
+	case orNode:
+		for _, child := range n {
+			collectTerms(child, negated, terms)
+		}
+	case notNode:
+		collectTerms(n.child, !negated, terms)
+	case termNode:
+		if negated {
+			return
+		}
+		for _, term := range n.terms {
+			if !slices.Contains(*terms, term) {
+				*terms = append(*terms, term)
+			}
+		}
+	}
+}
+
+// queryToken kinds
+const (
+	tokWord = iota
+	tokPhrase
+	tokField
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type queryToken struct {
+	kind   int
+	text   string // raw text, for error messages
+	field  string // for tokField
+	value  string // word, phrase or field value
+	offset int
+}
+
+type queryParser struct {
+	query  string
+	tokens []queryToken
+	pos    int
+	words  []queryWord
+	fields fieldMask
+}
+
+func (p *queryParser) errorAt(offset int, format string, args ...any) *QueryError {
+	return &QueryError{Query: p.query, Offset: offset, Message: fmt.Sprintf(format, args...)}
+}
+
+// lex splits the query into tokens
+func (p *queryParser) lex() error {
+	q := p.query
+	i := 0
+	for i < len(q) {
+		c := q[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, queryToken{kind: tokLParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, queryToken{kind: tokRParen, text: ")", offset: i})
+			i++
+		case c == '-':
+			p.tokens = append(p.tokens, queryToken{kind: tokNot, text: "-", offset: i})
+			i++
+		case c == '"':
+			value, end, err := p.lexPhrase(i)
+			if err != nil {
+				return err
+			}
+			p.tokens = append(p.tokens, queryToken{kind: tokPhrase, text: q[i:end], value: value, offset: i})
+			i = end
+		default:
+			start := i
+			for i < len(q) && !strings.ContainsRune(" \t\n\r()\"", rune(q[i])) {
+				if q[i] == ':' {
+					break
+				}
+				i++
+			}
+			word := q[start:i]
+
+			if i < len(q) && q[i] == ':' {
+				field := strings.ToLower(word)
+				if field == "" {
+					return p.errorAt(start, "missing field name before ':'")
+				}
+				if !slices.Contains(queryFields, field) {
+					return p.errorAt(start, "unknown field %q (expected one of %s)", word, strings.Join(queryFields, ", "))
+				}
+				i++
+				var value string
+				if i < len(q) && q[i] == '"' {
+					phrase, end, err := p.lexPhrase(i)
+					if err != nil {
+						return err
+					}
+					value, i = phrase, end
+				} else {
+					valueStart := i
+					for i < len(q) && !strings.ContainsRune(" \t\n\r()\"", rune(q[i])) {
+						i++
+					}
+					value = q[valueStart:i]
+				}
+				if strings.TrimSpace(value) == "" {
+					return p.errorAt(start, "missing value for field %q", field)
+				}
+				p.tokens = append(p.tokens, queryToken{kind: tokField, text: q[start:i], field: field, value: value, offset: start})
+				continue
+			}
+
+			switch word {
+			case "OR":
+				p.tokens = append(p.tokens, queryToken{kind: tokOr, text: word, offset: start})
+			case "NOT":
+				p.tokens = append(p.tokens, queryToken{kind: tokNot, text: word, offset: start})
+			default:
+				p.tokens = append(p.tokens, queryToken{kind: tokWord, text: word, value: word, offset: start})
+			}
+		}
+	}
+	return nil
+}
+
+// lexPhrase reads a double-quoted phrase starting at offset start
+func (p *queryParser) lexPhrase(start int) (string, int, error) {
+	end := strings.IndexByte(p.query[start+1:], '"')
+	if end < 0 {
+		return "", 0, p.errorAt(start, "unterminated quoted phrase")
+	}
+	end += start + 1
+	return p.query[start+1 : end], end + 1, nil
+}
+
+// parseOr parses "and (OR and)*"
+func (p *queryParser) parseOr() (queryNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes orNode
+	if first != nil {
+		nodes = append(nodes, first)
+	}
+	for p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokOr {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			nodes = append(nodes, next)
+		}
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+// parseAnd parses one or more unary terms. It returns a nil node when every
+// term was a stop word.
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var nodes andNode
+	start := p.pos
+	for p.pos < len(p.tokens) {
+		kind := p.tokens[p.pos].kind
+		if kind == tokOr || kind == tokRParen {
+			break
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		if p.pos > start {
+			return nil, nil
+		}
+		offset := len(p.query)
+		if p.pos < len(p.tokens) {
+			offset = p.tokens[p.pos].offset
+		}
+		return nil, p.errorAt(offset, "expected a search term")
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return nodes, nil
+}
+
+// parseUnary parses a negation, group, field filter, phrase or word. Words
+// made only of stop words yield a nil node.
+func (p *queryParser) parseUnary() (queryNode, error) {
+	tok := p.tokens[p.pos]
+	p.pos++
+
+	switch tok.kind {
+	case tokNot:
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind == tokOr || p.tokens[p.pos].kind == tokRParen {
+			return nil, p.errorAt(tok.offset, "nothing to negate after %q", tok.text)
+		}
+		if next := p.tokens[p.pos]; next.kind == tokField && next.field == "in" {
+			return nil, p.errorAt(next.offset, "in: cannot be negated")
+		}
+		child, err := p.parseUnary()
+		if err != nil || child == nil {
+			return nil, err
+		}
+		return notNode{child: child}, nil
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokRParen {
+			return nil, p.errorAt(tok.offset, "unclosed '('")
+		}
+		p.pos++
+		return node, nil
+	case tokField:
+		if tok.field == "in" {
+			field, ok := parseSearchField(tok.value)
+			if !ok {
+				return nil, p.errorAt(tok.offset, "unknown field %q for in: (expected one of %s)", tok.value, strings.Join(fieldNames[:], ", "))
+			}
+			p.fields |= 1 << field
+			return nil, nil
+		}
+		return fieldNode{field: tok.field, value: tok.value}, nil
+	case tokWord, tokPhrase:
+		base := tok.offset
+		if tok.kind == tokPhrase {
+			base++ // skip the opening quote
+		}
+		var terms []string
+		for _, t := range tokenize(tok.value) {
+			terms = append(terms, t.term)
+			p.words = append(p.words, queryWord{term: t.term, start: base + t.start, end: base + t.end})
+		}
+		if len(terms) == 0 {
+			if tok.kind == tokPhrase && strings.TrimSpace(tok.value) == "" {
+				return nil, p.errorAt(tok.offset, "empty quoted phrase")
+			}
+			return nil, nil
+		}
+		return termNode{terms: terms}, nil
+	}
+
+	return nil, p.errorAt(tok.offset, "unexpected %q", tok.text)
+}
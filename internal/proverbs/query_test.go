@@ -0,0 +1,197 @@
+package proverbs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// describeNode renders a parsed query as an s-expression
+func describeNode(node queryNode) string {
+	switch n := node.(type) {
+	case nil:
+		return "<nil>"
+	case andNode:
+		return describeNodes("and", n)
+	case orNode:
+		return describeNodes("or", n)
+	case notNode:
+		return "(not " + describeNode(n.child) + ")"
+	case termNode:
+		if len(n.terms) == 1 {
+			return n.terms[0]
+		}
+		return fmt.Sprintf("%q", strings.Join(n.terms, " "))
+	case fieldNode:
+		return n.field + ":" + n.value
+	}
+	return fmt.Sprintf("%T", node)
+}
+
+func describeNodes(op string, nodes []queryNode) string {
+	parts := []string{op}
+	for _, node := range nodes {
+		parts = append(parts, describeNode(node))
+	}
+	return "(" + strings.Join(parts, " ") + ")"
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query  string
+		tree   string
+		terms  []string
+		fields fieldMask
+	}{
+		{"", "<nil>", nil, allFields},
+		{"channels", "channels", []string{"channels"}, allFields},
+		{"Errors VALUES", "(and errors values)", []string{"errors", "values"}, allFields},
+		{"the and of", "<nil>", nil, allFields},
+		{"the channels", "channels", []string{"channels"}, allFields},
+		{`"clear is better"`, `"clear better"`, []string{"clear", "better"}, allFields},
+		{"a OR the", "<nil>", nil, allFields},
+		{"errors OR panic", "(or errors panic)", []string{"errors", "panic"}, allFields},
+		{"x OR y z", "(or x (and y z))", []string{"x", "y", "z"}, allFields},
+		{"(x OR y) z", "(and (or x y) z)", []string{"x", "y", "z"}, allFields},
+		{"-panic errors", "(and (not panic) errors)", []string{"errors"}, allFields},
+		{"NOT panic errors", "(and (not panic) errors)", []string{"errors"}, allFields},
+		{"--panic", "(not (not panic))", []string{"panic"}, allFields},
+		{"category:errors", "category:errors", nil, allFields},
+		{"Tag:interfaces", "tag:interfaces", nil, allFields},
+		{`author:"Rob Pike" channels`, "(and author:Rob Pike channels)", []string{"channels"}, allFields},
+		{"in:title channels", "channels", []string{"channels"}, 1 << FieldTitle},
+		{"channels in:CODE in:tags", "channels", []string{"channels"}, 1<<FieldCode | 1<<FieldTags},
+		{"x -x", "(and x (not x))", []string{"x"}, allFields},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			sq, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q) error: %v", tt.query, err)
+			}
+			if got := describeNode(sq.root); got != tt.tree {
+				t.Errorf("ParseQuery(%q) tree = %s, want %s", tt.query, got, tt.tree)
+			}
+			if !slices.Equal(sq.Terms(), tt.terms) {
+				t.Errorf("ParseQuery(%q) terms = %q, want %q", tt.query, sq.Terms(), tt.terms)
+			}
+			if sq.fields != tt.fields {
+				t.Errorf("ParseQuery(%q) fields = %05b, want %05b", tt.query, sq.fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestParseQueryWords(t *testing.T) {
+	sq, err := ParseQuery(`tag:x Errors "are values"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []queryWord{{"errors", 6, 12}, {"values", 18, 24}}
+	if !slices.Equal(sq.words, want) {
+		t.Errorf("words = %v, want %v", sq.words, want)
+	}
+}
+
+func TestParseQueryErrors(t *testing.T) {
+	tests := []struct {
+		query   string
+		offset  int
+		message string
+	}{
+		{`"unterminated`, 0, "unterminated quoted phrase"},
+		{`x ""`, 2, "empty quoted phrase"},
+		{"(x", 0, "unclosed '('"},
+		{"x)", 1, `unexpected ")"`},
+		{"()", 1, "expected a search term"},
+		{"x OR", 4, "expected a search term"},
+		{"OR x", 0, "expected a search term"},
+		{"x -", 2, `nothing to negate after "-"`},
+		{"NOT OR x", 0, `nothing to negate after "NOT"`},
+		{"-in:code", 1, "in: cannot be negated"},
+		{"in:body", 0, `unknown field "body" for in:`},
+		{"x color:red", 2, `unknown field "color"`},
+		{":x", 0, "missing field name before ':'"},
+		{"tag:", 0, `missing value for field "tag"`},
+		{`tag:""`, 0, `missing value for field "tag"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseQuery(tt.query)
+			var qe *QueryError
+			if !errors.As(err, &qe) {
+				t.Fatalf("ParseQuery(%q) error = %v, want a *QueryError", tt.query, err)
+			}
+			if qe.Offset != tt.offset || !strings.HasPrefix(qe.Message, tt.message) {
+				t.Errorf("ParseQuery(%q) error at %d %q, want at %d %q", tt.query, qe.Offset, qe.Message, tt.offset, tt.message)
+			}
+			if qe.Query != tt.query {
+				t.Errorf("ParseQuery(%q) error query = %q", tt.query, qe.Query)
+			}
+		})
+	}
+}
+
+func TestRunSuggestions(t *testing.T) {
+	idx := NewSearchIndex([]Proverb{
+		{ID: "a", Title: "Channels orchestrate", Text: "Channels orchestrate; mutexes serialize."},
+		{ID: "b", Title: "Errors are values", Text: "Errors are values."},
+		{ID: "c", Title: "Clear is better", Text: "Clear is better than clever.", Example: `fmt.Println("hi")`},
+	})
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"channels", nil},
+		{"chanels", nil},
+		{"chanels errors", []string{"channels errors"}},
+		{"chanels orchestrate", nil},
+		{"chanels OR qwerty", []string{"channels OR qwerty"}},
+		{"mutexs -chanels", []string{"mutexes -channels"}},
+		{"printn qqqqzz", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			sq, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := idx.Run(sq).Suggestions; !slices.Equal(got, tt.want) {
+				t.Errorf("Run(%q) suggestions = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunPrefixes(t *testing.T) {
+	idx := NewSearchIndex([]Proverb{
+		{ID: "a", Title: "Channels orchestrate", Text: "Channels orchestrate; mutexes serialize."},
+		{ID: "b", Title: "Buffered channel", Text: "A buffered channel is a queue."},
+		{ID: "c", Title: "Errors are values", Text: "Errors are values."},
+	})
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"channel", []string{"b", "a"}},
+		{"channels", []string{"a"}},
+		{"value", []string{"c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			sq, err := ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []string
+			for _, r := range idx.Run(sq).Results {
+				ids = append(ids, r.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("Run(%q) = %q, want %q", tt.query, ids, tt.want)
+			}
+		})
+	}
+}
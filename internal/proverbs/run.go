@@ -0,0 +1,295 @@
+package proverbs
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	gotoken "go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Runner defaults
+const (
+	DefaultRunTimeout     = 10 * time.Second
+	DefaultRunMemoryLimit = 256 << 20 // bytes
+	maxRunOutput          = 64 << 10  // bytes kept of each output stream
+	maxConcurrentRuns     = 2
+	maxCachedRuns         = 256
+)
+
+// ErrNoExample is returned when running a proverb without an example
+var ErrNoExample = errors.New("proverb has no example")
+
+// ErrNotRunnable is returned when running an example whose front matter
+// declares it is not runnable
+var ErrNotRunnable = errors.New("example is not runnable")
+
+// ErrNoToolchain is returned when no go command is available to build
+// examples with
+var ErrNoToolchain = errors.New("go toolchain not found")
+
+// runSlots bounds how many examples build and run at once
+var runSlots = make(chan struct{}, maxConcurrentRuns)
+
+// runKey identifies an example run: the same source built under the same
+// name and limits gives the same result, as examples read no input
+type runKey struct {
+	file string
+	sum  [sha256.Size]byte
+	opts RunOptions
+}
+
+// runCache holds the results of finished runs, so running an example again
+// does not take a run slot. It is emptied when it reaches maxCachedRuns.
+var runCache struct {
+	sync.Mutex
+	results map[runKey]RunResult
+}
+
+// RunOptions limits an example run. Zero values select the defaults.
+type RunOptions struct {
+	Timeout     time.Duration
+	MemoryLimit int64
+}
+
+// RunResult is the outcome of building and running an example. Stage is
+// "build" when the example failed to compile, in which case Stderr holds
+// the compiler output, and "run" otherwise. When the example declares its
+// output, Expected holds it and Passed whether stdout matched.
+type RunResult struct {
+	Stage     string `json:"stage"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	ExitCode  int    `json:"exit_code"`
+	TimedOut  bool   `json:"timed_out,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Duration  int64  `json:"duration_ms"`
+	Expected  string `json:"expected,omitempty"`
+	Passed    *bool  `json:"passed,omitempty"`
+}
+
+// RunExample builds the proverb's example as a main package with the local
+// go toolchain in a temporary directory and runs it, capturing its output.
+// Fragments are laid out as for CheckExample, with statements placed in
+// main; an example without statements gets an empty main. The module uses
+// the Go version the example declares and can only import the standard
+// library. Building and running share the timeout, and the program's memory
+// is limited where the platform allows. Neither sees the server's
+// environment; see runEnv. Results are cached by example source, except for
+// runs that timed out.
+func RunExample(ctx context.Context, proverb Proverb, opts RunOptions) (*RunResult, error) {
+	if strings.TrimSpace(proverb.Example) == "" {
+		return nil, ErrNoExample
+	}
+	meta, _, _ := ParseFrontMatter(proverb.Example)
+	if !meta.Runnable {
+		return nil, ErrNotRunnable
+	}
+	goVersion := cmp.Or(meta.GoVersion, defaultGoVersion)
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		return nil, ErrNoToolchain
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultRunTimeout
+	}
+	if opts.MemoryLimit <= 0 {
+		opts.MemoryLimit = DefaultRunMemoryLimit
+	}
+
+	key := runKey{file: ExampleFile(proverb), sum: sha256.Sum256([]byte(proverb.Example)), opts: opts}
+	runCache.Lock()
+	cached, ok := runCache.results[key]
+	runCache.Unlock()
+	if ok {
+		return &cached, nil
+	}
+	result, err := runExample(ctx, goCmd, goVersion, meta, proverb, opts)
+	if err == nil && !result.TimedOut {
+		runCache.Lock()
+		if runCache.results == nil || len(runCache.results) >= maxCachedRuns {
+			runCache.results = make(map[runKey]RunResult)
+		}
+		runCache.results[key] = *result
+		runCache.Unlock()
+	}
+	return result, err
+}
+
+// runExample builds and runs an example for RunExample with the resolved
+// go command, Go version and limits
+func runExample(ctx context.Context, goCmd, goVersion string, meta ExampleMeta, proverb Proverb, opts RunOptions) (*RunResult, error) {
+	select {
+	case runSlots <- struct{}{}:
+		defer func() { <-runSlots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	dir, err := os.MkdirTemp("", "proverb-run-")
+	if err != nil {
+		return nil, fmt.Errorf("creating run directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := mainSource(ExampleFile(proverb), proverb.Example, meta.Imports)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		return nil, fmt.Errorf("writing example: %w", err)
+	}
+	goMod := fmt.Sprintf("module example\n\ngo %s\n", goVersion)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0o644); err != nil {
+		return nil, fmt.Errorf("writing go.mod: %w", err)
+	}
+
+	start := time.Now()
+	result := &RunResult{Stage: "build"}
+	want, unordered, hasOutput := ExpectedOutput(proverb.Example)
+	defer func() {
+		if hasOutput {
+			passed := result.Stage == "run" && result.ExitCode == 0 && !result.TimedOut &&
+				OutputMatches(result.Stdout, want, unordered)
+			result.Expected, result.Passed = want, &passed
+		}
+	}()
+	build := exec.CommandContext(ctx, goCmd, "build", "-o", "example", ".")
+	build.Dir = dir
+	build.Env = runEnv(dir,
+		"GOCACHE="+filepath.Join(os.TempDir(), runCacheDir),
+		"GOPATH="+filepath.Join(dir, "gopath"),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=readonly",
+		"GOTOOLCHAIN=local",
+		"CGO_ENABLED=0",
+	)
+	if err := execute(ctx, build, result); err != nil {
+		return nil, fmt.Errorf("building example: %w", err)
+	}
+	if result.ExitCode != 0 || result.TimedOut {
+		result.Duration = time.Since(start).Milliseconds()
+		return result, nil
+	}
+
+	*result = RunResult{Stage: "run"}
+	run := exec.CommandContext(ctx, filepath.Join(dir, "example"))
+	limitMemory(run, opts.MemoryLimit)
+	run.Dir = dir
+	run.Env = runEnv(dir, fmt.Sprintf("GOMEMLIMIT=%d", opts.MemoryLimit))
+	if err := execute(ctx, run, result); err != nil {
+		return nil, fmt.Errorf("running example: %w", err)
+	}
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
+
+// runCacheDir is the build cache in the system temporary directory shared
+// by example builds, so each one does not rebuild the standard library
+const runCacheDir = "proverb-run-cache"
+
+// runEnv returns the environment an example is built or run with: PATH, to
+// find the go command, HOME set to the run directory, and extra. Nothing
+// else of the server's environment, such as credentials, is passed on.
+func runEnv(dir string, extra ...string) []string {
+	return append([]string{"PATH=" + os.Getenv("PATH"), "HOME=" + dir}, extra...)
+}
+
+// execute runs cmd, recording its output, exit code and whether it timed
+// out in result. It only returns an error when cmd could not be started.
+func execute(ctx context.Context, cmd *exec.Cmd, result *RunResult) error {
+	stdout := &limitedBuffer{limit: maxRunOutput}
+	stderr := &limitedBuffer{limit: maxRunOutput}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Run()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.Truncated = stdout.truncated || stderr.truncated
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case cmd.ProcessState == nil:
+		return err
+	}
+	result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	return nil
+}
+
+// mainSource turns an example into the source of a main package, importing
+// the declared imports it uses. Source that does not parse is returned in
+// its closest layout for the compiler to report.
+func mainSource(file, src string, imports []string) string {
+	layout, _ := layoutExample("", file, src)
+	if !layout.wrap {
+		return renameToMain(src)
+	}
+	layout.imports = imports
+
+	f, out, err := layout.parse(gotoken.NewFileSet(), "main", "main")
+	if err == nil && !layout.hasBody() && !hasMainFunc(f) {
+		out += "\nfunc main() {}\n"
+	}
+	return out
+}
+
+// renameToMain renames the package of a complete program to main and
+// gives it an empty main function if it has none
+func renameToMain(src string) string {
+	fset := gotoken.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return src
+	}
+	offset := fset.Position(f.Name.Pos()).Offset
+	out := src[:offset] + "main" + src[offset+len(f.Name.Name):]
+	if !hasMainFunc(f) {
+		out += "\n\nfunc main() {}\n"
+	}
+	return out
+}
+
+// hasMainFunc reports whether the file declares func main
+func hasMainFunc(f *ast.File) bool {
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+// limitedBuffer keeps the first limit bytes written to it. The buffer is
+// not embedded so io.Copy cannot bypass Write through ReadFrom.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); len(p) > room {
+		b.truncated = true
+		b.buf.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	return b.buf.String()
+}
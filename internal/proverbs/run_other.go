@@ -0,0 +1,9 @@
+//go:build !unix
+
+package proverbs
+
+import "os/exec"
+
+// limitMemory leaves cmd unchanged: only the GOMEMLIMIT soft limit
+// applies on this platform
+func limitMemory(cmd *exec.Cmd, limit int64) {}
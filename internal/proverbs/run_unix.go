@@ -0,0 +1,18 @@
+//go:build unix
+
+package proverbs
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// limitMemory caps the data segment of cmd with the shell's ulimit, as
+// exec.Cmd cannot set resource limits itself. The address space is left
+// alone because the Go runtime reserves far more of it than it uses.
+func limitMemory(cmd *exec.Cmd, limit int64) {
+	script := fmt.Sprintf(`ulimit -d %d && exec "$0" "$@"`, limit>>10)
+	cmd.Args = append([]string{"sh", "-c", script}, cmd.Args...)
+	cmd.Path = "/bin/sh"
+	cmd.Err = nil
+}
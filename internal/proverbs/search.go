@@ -0,0 +1,342 @@
+package proverbs
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SearchField identifies an indexed proverb field
+type SearchField int
+
+const (
+	FieldTitle SearchField = iota
+	FieldText
+	FieldExplanation
+	FieldTags
+	FieldCode
+	numSearchFields
+)
+
+// fieldNames are the JSON names of the indexed fields
+var fieldNames = [numSearchFields]string{"title", "text", "explanation", "tags", "code"}
+
+// fieldMask is a set of SearchFields
+type fieldMask uint8
+
+// allFields selects every indexed field
+const allFields fieldMask = 1<<numSearchFields - 1
+
+// has reports whether the mask includes the field
+func (m fieldMask) has(f SearchField) bool {
+	return m&(1<<f) != 0
+}
+
+// String returns the field's JSON name
+func (f SearchField) String() string {
+	if f < 0 || f >= numSearchFields {
+		return "unknown"
+	}
+	return fieldNames[f]
+}
+
+// fieldWeights boost matches in some fields over others. A title hit counts
+// three times as much as one in the body text.
+var fieldWeights = [numSearchFields]float64{
+	FieldTitle:       3.0,
+	FieldText:        1.0,
+	FieldExplanation: 1.0,
+	FieldTags:        1.5,
+	FieldCode:        0.5,
+}
+
+// BM25 parameters: k1 controls term frequency saturation and b how much
+// field length normalizes the score
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// minPrefixLen is the shortest query term expanded to the indexed terms it
+// prefixes, so "concurr" finds "concurrency" and "channel" finds "channels"
+const minPrefixLen = 3
+
+// prefixWeight scales the score of the indexed terms a query term expands
+// to other than itself, so an exact match ranks above a longer word or a
+// typo correction
+const prefixWeight = 0.5
+
+// stopWords are not indexed and dropped from queries
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "with": true,
+}
+
+// SearchResult is a proverb matched by a search together with its score
+// and where the search terms were found: spans in the title, text and
+// explanation keyed by field name, an explanation snippet around the first
+// hit, and the example lines they occur on
+type SearchResult struct {
+	Proverb
+	Score      float64           `json:"score"`
+	Highlights map[string][]Span `json:"highlights,omitempty"`
+	Snippet    *Snippet          `json:"snippet,omitempty"`
+	CodeHits   []CodeHit         `json:"code_hits,omitempty"`
+}
+
+// SearchResponse holds the ranked results of a search and, when some words
+// look misspelled, corrected queries to offer instead
+type SearchResponse struct {
+	Results     []SearchResult `json:"results"`
+	Suggestions []string       `json:"suggestions,omitempty"`
+}
+
+// Searcher is implemented by stores that maintain their own search index
+type Searcher interface {
+	Search(query string) (*SearchResponse, error)
+}
+
+// Search runs a ranked search against the store, using its own index when
+// it has one and building a temporary index otherwise. The query syntax is
+// described on SearchQuery; malformed queries return a *QueryError.
+func Search(store Store, query string) (*SearchResponse, error) {
+	if searcher, ok := store.(Searcher); ok {
+		return searcher.Search(query)
+	}
+	all, err := store.List()
+	if err != nil {
+		return nil, err
+	}
+	return NewSearchIndex(all).Search(query)
+}
+
+// posting records how often a term occurs in each field of one document
+type posting struct {
+	doc  int
+	freq [numSearchFields]int
+}
+
+// SearchIndex is an inverted index over proverb titles, texts, explanations,
+// tags and example code, ranked with BM25F, together with a prefix trie of
+// titles, tags and categories for completion. It is immutable once built.
+type SearchIndex struct {
+	docs     []Proverb
+	postings map[string][]posting
+	terms    []string // sorted vocabulary, for prefix expansion
+	words    []string // terms used in prose fields, for spelling correction
+	fieldLen [][numSearchFields]int
+	avgLen   [numSearchFields]float64
+
+	completions *completionTrie
+}
+
+// NewSearchIndex builds an index over the given proverbs
+func NewSearchIndex(proverbs []Proverb) *SearchIndex {
+	idx := &SearchIndex{
+		docs:     proverbs,
+		postings: make(map[string][]posting),
+		fieldLen: make([][numSearchFields]int, len(proverbs)),
+	}
+
+	var totalLen [numSearchFields]int
+	for doc, proverb := range proverbs {
+		freqs := make(map[string]*posting)
+		for field, tokens := range fieldTokens(proverb) {
+			idx.fieldLen[doc][field] = len(tokens)
+			totalLen[field] += len(tokens)
+			for _, tok := range tokens {
+				p, ok := freqs[tok.term]
+				if !ok {
+					p = &posting{doc: doc}
+					freqs[tok.term] = p
+				}
+				p.freq[field]++
+			}
+		}
+		for term, p := range freqs {
+			idx.postings[term] = append(idx.postings[term], *p)
+		}
+	}
+
+	for field := range totalLen {
+		if len(proverbs) > 0 {
+			idx.avgLen[field] = float64(totalLen[field]) / float64(len(proverbs))
+		}
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	slices.Sort(idx.terms)
+	for _, term := range idx.terms {
+		if idx.inProse(term) {
+			idx.words = append(idx.words, term)
+		}
+	}
+
+	idx.completions = newCompletionTrie(proverbs)
+
+	return idx
+}
+
+// inProse reports whether term occurs outside of example code in any
+// proverb. Identifiers that only appear in code make poor corrections.
+func (idx *SearchIndex) inProse(term string) bool {
+	for _, p := range idx.postings[term] {
+		for field := range numSearchFields {
+			if field != FieldCode && p.freq[field] > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Search parses the query with ParseQuery and runs it, best match first.
+// Ties are broken by ID.
+func (idx *SearchIndex) Search(query string) (*SearchResponse, error) {
+	sq, err := ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return idx.Run(sq), nil
+}
+
+// expand returns the indexed terms a query term matches: itself when
+// indexed, every term it is a prefix of, and failing both the closest terms
+// by edit distance
+func (idx *SearchIndex) expand(term string) []string {
+	var matches []string
+	if len(term) >= minPrefixLen {
+		i, _ := slices.BinarySearch(idx.terms, term)
+		for ; i < len(idx.terms) && strings.HasPrefix(idx.terms[i], term); i++ {
+			matches = append(matches, idx.terms[i])
+		}
+	} else if _, ok := idx.postings[term]; ok {
+		matches = []string{term}
+	}
+	if len(matches) == 0 {
+		matches = idx.fuzzyExpand(term)
+	}
+	return matches
+}
+
+// scoreTerm adds the BM25F contribution of one term, scaled by weight and
+// counting only occurrences in the masked fields, to each matching document
+func (idx *SearchIndex) scoreTerm(term string, weight float64, fields fieldMask, scores map[int]float64) {
+	postings := idx.postings[term]
+	if len(postings) == 0 {
+		return
+	}
+
+	n := float64(len(idx.docs))
+	df := float64(len(postings))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+	for _, p := range postings {
+		var tf float64
+		for field := range numSearchFields {
+			if p.freq[field] == 0 || !fields.has(field) {
+				continue
+			}
+			norm := 1.0
+			if idx.avgLen[field] > 0 {
+				norm = 1 - bm25B + bm25B*float64(idx.fieldLen[p.doc][field])/idx.avgLen[field]
+			}
+			tf += fieldWeights[field] * float64(p.freq[field]) / norm
+		}
+		if tf > 0 {
+			scores[p.doc] += weight * idf * tf / (bm25K1 + tf)
+		}
+	}
+}
+
+// SortResults orders search results in place. Relevance sorts by score;
+// every other field sorts like SortProverbs.
+func SortResults(results []SearchResult, s Sort) {
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if s.Field != SortByRelevance {
+			return compareProverbs(a.Proverb, b.Proverb, s)
+		}
+		c := cmp.Compare(a.Score, b.Score)
+		if s.Order == OrderDesc {
+			c = -c
+		}
+		if c == 0 {
+			c = cmp.Compare(a.ID, b.ID)
+		}
+		return c
+	})
+}
+
+// fieldText returns the searchable text of each field
+func fieldText(proverb Proverb) [numSearchFields]string {
+	return [numSearchFields]string{
+		FieldTitle:       proverb.Title,
+		FieldText:        proverb.Text,
+		FieldExplanation: proverb.Explanation,
+		FieldTags:        strings.Join(proverb.Tags, " "),
+		FieldCode:        proverb.Example,
+	}
+}
+
+// fieldTokens tokenizes each field; example code is split with the Go
+// scanner rather than as prose
+func fieldTokens(proverb Proverb) [numSearchFields][]token {
+	var tokens [numSearchFields][]token
+	for field, text := range fieldText(proverb) {
+		if SearchField(field) == FieldCode {
+			tokens[field] = goTokenize(text)
+		} else {
+			tokens[field] = tokenize(text)
+		}
+	}
+	return tokens
+}
+
+// token is a normalized term and its byte offsets in the source text. Code
+// tokens also carry their line and column.
+type token struct {
+	term       string
+	start, end int
+	line, col  int
+}
+
+// tokenize splits text into lowercase terms on anything that is not a
+// letter or digit, dropping stop words
+func tokenize(text string) []token {
+	var tokens []token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		term := strings.ToLower(text[start:end])
+		if !stopWords[term] {
+			tokens = append(tokens, token{term: term, start: start, end: end})
+		}
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+// roundScore keeps scores readable in JSON
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
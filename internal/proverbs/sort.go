@@ -0,0 +1,115 @@
+package proverbs
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortField names the proverb field listings are ordered by
+type SortField string
+
+const (
+	SortByID         SortField = "id"
+	SortByTitle      SortField = "title"
+	SortByCreatedAt  SortField = "created_at"
+	SortByCategory   SortField = "category"
+	SortByPopularity SortField = "popularity"
+	SortByRelevance  SortField = "relevance"
+)
+
+// SortFields lists every supported sort field in display order
+var SortFields = []SortField{SortByID, SortByTitle, SortByCreatedAt, SortByCategory, SortByPopularity}
+
+// SearchSortFields adds search relevance to SortFields
+var SearchSortFields = append([]SortField{SortByRelevance}, SortFields...)
+
+// SortOrder is the direction of a sort
+type SortOrder string
+
+const (
+	OrderAsc  SortOrder = "asc"
+	OrderDesc SortOrder = "desc"
+)
+
+// Sort describes how a listing is ordered. The zero value sorts by ID in
+// ascending order. Ties are always broken by ID so results are stable.
+type Sort struct {
+	Field SortField `json:"sort"`
+	Order SortOrder `json:"order"`
+}
+
+// DefaultSort is the order used when none is requested
+var DefaultSort = Sort{Field: SortByID, Order: OrderAsc}
+
+// ParseSort validates sort and order parameters. An empty field selects ID
+// and an empty order selects ascending, except for popularity, which
+// defaults to most popular first.
+func ParseSort(field, order string) (Sort, error) {
+	return parseSort(field, order, SortFields, SortByID)
+}
+
+// ParseSearchSort is ParseSort for search results, which additionally
+// accept and default to relevance, best match first
+func ParseSearchSort(field, order string) (Sort, error) {
+	return parseSort(field, order, SearchSortFields, SortByRelevance)
+}
+
+// parseSort validates a sort against the allowed fields
+func parseSort(field, order string, allowed []SortField, def SortField) (Sort, error) {
+	s := Sort{
+		Field: SortField(strings.ToLower(strings.TrimSpace(field))),
+		Order: SortOrder(strings.ToLower(strings.TrimSpace(order))),
+	}
+	if s.Field == "" {
+		s.Field = def
+	}
+	if !slices.Contains(allowed, s.Field) {
+		return Sort{}, fmt.Errorf("invalid sort field %q", field)
+	}
+
+	switch s.Order {
+	case "":
+		s.Order = OrderAsc
+		if s.Field == SortByPopularity || s.Field == SortByRelevance {
+			s.Order = OrderDesc
+		}
+	case OrderAsc, OrderDesc:
+	default:
+		return Sort{}, fmt.Errorf("invalid sort order %q", order)
+	}
+	return s, nil
+}
+
+// SortProverbs orders proverbs in place
+func SortProverbs(proverbs []Proverb, s Sort) {
+	slices.SortStableFunc(proverbs, func(a, b Proverb) int {
+		return compareProverbs(a, b, s)
+	})
+}
+
+// compareProverbs orders two proverbs by the sort field, breaking ties
+// between other fields by ID
+func compareProverbs(a, b Proverb, s Sort) int {
+	var c int
+	switch s.Field {
+	case SortByID:
+		c = cmp.Compare(a.ID, b.ID)
+	case SortByTitle:
+		c = cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case SortByCreatedAt:
+		c = a.CreatedAt.Compare(b.CreatedAt)
+	case SortByCategory:
+		c = cmp.Compare(a.Category, b.Category)
+	case SortByPopularity:
+		c = cmp.Compare(a.Popularity, b.Popularity)
+	}
+	if s.Order == OrderDesc {
+		c = -c
+	}
+	if c == 0 {
+		c = cmp.Compare(a.ID, b.ID)
+	}
+	return c
+}
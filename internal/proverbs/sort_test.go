@@ -0,0 +1,38 @@
+package proverbs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompareProverbs(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := Proverb{ID: "a", Title: "Zebra", CreatedAt: day, Category: "errors", Popularity: 5}
+	b := Proverb{ID: "b", Title: "apple", CreatedAt: day.AddDate(0, 0, 1), Category: "concurrency", Popularity: 5}
+
+	tests := []struct {
+		name string
+		a, b Proverb
+		sort Sort
+		want int
+	}{
+		{"id asc", a, b, Sort{SortByID, OrderAsc}, -1},
+		{"id desc", a, b, Sort{SortByID, OrderDesc}, 1},
+		{"zero value is id asc", a, b, Sort{}, -1},
+		{"title ignores case", a, b, Sort{SortByTitle, OrderAsc}, 1},
+		{"title desc", a, b, Sort{SortByTitle, OrderDesc}, -1},
+		{"created_at asc", a, b, Sort{SortByCreatedAt, OrderAsc}, -1},
+		{"created_at desc", a, b, Sort{SortByCreatedAt, OrderDesc}, 1},
+		{"category asc", a, b, Sort{SortByCategory, OrderAsc}, 1},
+		{"popularity tie broken by id", a, b, Sort{SortByPopularity, OrderDesc}, -1},
+		{"tie break ignores desc", b, a, Sort{SortByPopularity, OrderDesc}, 1},
+		{"same proverb", a, a, Sort{SortByID, OrderDesc}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := compareProverbs(tt.a, tt.b, tt.sort); got != tt.want {
+				t.Errorf("compareProverbs(%s, %s, %v) = %d, want %d", tt.a.ID, tt.b.ID, tt.sort, got, tt.want)
+			}
+		})
+	}
+}
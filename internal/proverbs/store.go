@@ -0,0 +1,348 @@
+package proverbs
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a proverb does not exist in a store
+var ErrNotFound = errors.New("proverb not found")
+
+// Store is a storage backend for proverbs. ProverbCollection is the
+// in-memory implementation and FileStore persists to a JSON file.
+type Store interface {
+	// Get returns the proverb with the given ID or ErrNotFound
+	Get(id string) (Proverb, error)
+	// List returns every stored proverb
+	List() ([]Proverb, error)
+	// Query returns the proverbs matching q
+	Query(q Query) ([]Proverb, error)
+	// Put creates or replaces the proverb with proverb.ID
+	Put(proverb Proverb) error
+	// Delete removes the proverb with the given ID or returns ErrNotFound
+	Delete(id string) error
+}
+
+// Query filters proverbs. Zero-valued fields match every proverb.
+type Query struct {
+	Category Category
+	Source   Source
+	Tag      string
+	// Text matches case-insensitively against title, text, explanation and tags
+	Text string
+	// GoVersion keeps proverbs whose example builds with this Go version,
+	// e.g. 1.22
+	GoVersion string
+	// Sort orders the results; the zero value orders by ID
+	Sort Sort
+}
+
+// ViewRecorder is implemented by stores that track proverb popularity
+type ViewRecorder interface {
+	RecordView(id string)
+}
+
+// Matches reports whether the proverb satisfies every filter in the query
+func (q Query) Matches(proverb Proverb) bool {
+	if q.Category != "" && proverb.Category != q.Category {
+		return false
+	}
+	if q.Source != "" && proverb.Source != q.Source {
+		return false
+	}
+	if q.Tag != "" && !hasTag(proverb, q.Tag) {
+		return false
+	}
+	if q.Text != "" && !containsText(proverb, strings.ToLower(q.Text)) {
+		return false
+	}
+	if q.GoVersion != "" && !RunsOn(proverb.MinGoVersion(), q.GoVersion) {
+		return false
+	}
+	return true
+}
+
+// hasTag reports whether the proverb carries the given tag
+func hasTag(proverb Proverb, tag string) bool {
+	for _, proverbTag := range proverb.Tags {
+		if proverbTag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// containsText reports whether any searchable field contains the lowercased query
+func containsText(proverb Proverb, queryLower string) bool {
+	if strings.Contains(strings.ToLower(proverb.Title), queryLower) ||
+		strings.Contains(strings.ToLower(proverb.Text), queryLower) ||
+		strings.Contains(strings.ToLower(proverb.Explanation), queryLower) {
+		return true
+	}
+	for _, tag := range proverb.Tags {
+		if strings.Contains(strings.ToLower(tag), queryLower) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns the proverb with the given ID
+func (pc *ProverbCollection) Get(id string) (Proverb, error) {
+	if proverb := pc.GetByID(id); proverb != nil {
+		return *proverb, nil
+	}
+	return Proverb{}, ErrNotFound
+}
+
+// List returns every proverb in the collection, ordered by ID
+func (pc *ProverbCollection) List() ([]Proverb, error) {
+	return pc.GetAll(), nil
+}
+
+// Query returns the proverbs matching q in the order q.Sort requests
+func (pc *ProverbCollection) Query(q Query) ([]Proverb, error) {
+	result := filterProverbs(pc.GetAll(), q)
+	SortProverbs(result, q.Sort)
+	return result, nil
+}
+
+// Put stores the proverb under its ID in the map for its source
+func (pc *ProverbCollection) Put(proverb Proverb) error {
+	if err := pc.put(proverb); err != nil {
+		return err
+	}
+	pc.invalidateIndex()
+	return nil
+}
+
+// put stores the proverb without touching the search index
+func (pc *ProverbCollection) put(proverb Proverb) error {
+	id := proverb.ID
+	if id == "" {
+		return fmt.Errorf("proverb ID is required")
+	}
+	proverb.ExampleParts = ParseExample(proverb.Example)
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	switch proverb.Source {
+	case SourceOfficial:
+		if pc.Official == nil {
+			pc.Official = make(map[string]Proverb)
+		}
+		delete(pc.Community, id)
+		pc.Official[id] = proverb
+	case SourceCommunity:
+		if pc.Community == nil {
+			pc.Community = make(map[string]Proverb)
+		}
+		delete(pc.Official, id)
+		pc.Community[id] = proverb
+	default:
+		return fmt.Errorf("invalid source: %s", proverb.Source)
+	}
+	return nil
+}
+
+// Delete removes the proverb with the given ID
+func (pc *ProverbCollection) Delete(id string) error {
+	if err := pc.delete(id); err != nil {
+		return err
+	}
+	pc.invalidateIndex()
+	return nil
+}
+
+// delete removes the proverb without touching the search index
+func (pc *ProverbCollection) delete(id string) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if _, exists := pc.Official[id]; exists {
+		delete(pc.Official, id)
+		return nil
+	}
+	if _, exists := pc.Community[id]; exists {
+		delete(pc.Community, id)
+		return nil
+	}
+	return ErrNotFound
+}
+
+// Search runs a ranked search over the collection. Results carry the
+// current proverb rather than the copy taken when the index was built.
+func (pc *ProverbCollection) Search(query string) (*SearchResponse, error) {
+	response, err := pc.searchIndex().Search(query)
+	if err != nil {
+		return nil, err
+	}
+	for i, result := range response.Results {
+		if current := pc.GetByID(result.ID); current != nil {
+			response.Results[i].Proverb = *current
+		}
+	}
+	return response, nil
+}
+
+// Complete returns completions for prefix from the collection's trie,
+// ranked by the proverbs' current popularity
+func (pc *ProverbCollection) Complete(prefix string, limit int) ([]Completion, error) {
+	completions := pc.searchIndex().completions.lookup(prefix)
+	for i, c := range completions {
+		if c.Kind != CompleteTitle {
+			continue
+		}
+		if current := pc.GetByID(c.ID); current != nil {
+			completions[i].Popularity = current.Popularity
+		}
+	}
+	return rankCompletions(completions, limit), nil
+}
+
+// searchIndex returns the collection's search index, building it on first use
+func (pc *ProverbCollection) searchIndex() *SearchIndex {
+	pc.indexMu.Lock()
+	defer pc.indexMu.Unlock()
+
+	if pc.index == nil {
+		pc.index = NewSearchIndex(pc.GetAll())
+	}
+	return pc.index
+}
+
+// invalidateIndex drops the search index so the next search rebuilds it
+func (pc *ProverbCollection) invalidateIndex() {
+	pc.indexMu.Lock()
+	defer pc.indexMu.Unlock()
+
+	pc.index = nil
+}
+
+// filterProverbs returns the proverbs matching q, keeping their order
+func filterProverbs(proverbs []Proverb, q Query) []Proverb {
+	var result []Proverb
+	for _, proverb := range proverbs {
+		if q.Matches(proverb) {
+			result = append(result, proverb)
+		}
+	}
+	return result
+}
+
+// FileStore is a Store persisted to a JSON file. Every Put and Delete
+// rewrites the file through SaveToFile before changing the collection.
+type FileStore struct {
+	filename   string
+	mu         sync.Mutex
+	collection *ProverbCollection
+}
+
+// NewFileStore opens the JSON store at filename. If the file does not
+// exist yet it is created from seed.
+func NewFileStore(filename string, seed *ProverbCollection) (*FileStore, error) {
+	collection, err := LoadFromFile(filename)
+	if errors.Is(err, os.ErrNotExist) && seed != nil {
+		if err := seed.SaveToFile(filename); err != nil {
+			return nil, err
+		}
+		collection, err = LoadFromFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	collection.searchIndex()
+
+	return &FileStore{
+		filename:   filename,
+		collection: collection,
+	}, nil
+}
+
+// Get returns the proverb with the given ID
+func (s *FileStore) Get(id string) (Proverb, error) {
+	return s.collection.Get(id)
+}
+
+// List returns every stored proverb
+func (s *FileStore) List() ([]Proverb, error) {
+	return s.collection.List()
+}
+
+// Query returns the proverbs matching q
+func (s *FileStore) Query(q Query) ([]Proverb, error) {
+	return s.collection.Query(q)
+}
+
+// Search runs a ranked search over the stored proverbs
+func (s *FileStore) Search(query string) (*SearchResponse, error) {
+	return s.collection.Search(query)
+}
+
+// Complete returns completions for prefix from the stored titles, tags and
+// categories
+func (s *FileStore) Complete(prefix string, limit int) ([]Completion, error) {
+	return s.collection.Complete(prefix, limit)
+}
+
+// ValidateCollection validates the stored proverbs
+func (s *FileStore) ValidateCollection() []ValidationError {
+	return s.collection.ValidateCollection()
+}
+
+// RecordView counts a view of the proverb. Views are kept in memory and
+// written with the next Put or Delete.
+func (s *FileStore) RecordView(id string) {
+	s.collection.RecordView(id)
+}
+
+// Put writes the file with the proverb stored and then stores it, so a
+// failed write leaves the store unchanged
+func (s *FileStore) Put(proverb Proverb) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.collection.snapshot()
+	if err := next.put(proverb); err != nil {
+		return err
+	}
+	if err := next.SaveToFile(s.filename); err != nil {
+		return err
+	}
+	return s.collection.Put(proverb)
+}
+
+// Delete writes the file without the proverb and then removes it, so a
+// failed write leaves the store unchanged
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.collection.snapshot()
+	if err := next.delete(id); err != nil {
+		return err
+	}
+	if err := next.SaveToFile(s.filename); err != nil {
+		return err
+	}
+	return s.collection.Delete(id)
+}
+
+// snapshot returns a copy of the proverbs in the collection, without its
+// search index
+func (pc *ProverbCollection) snapshot() *ProverbCollection {
+	pc.mu.RLock()
+	defer pc.mu.RUnlock()
+
+	return &ProverbCollection{
+		Official:  maps.Clone(pc.Official),
+		Community: maps.Clone(pc.Community),
+		UpdatedAt: pc.UpdatedAt,
+	}
+}
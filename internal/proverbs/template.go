@@ -0,0 +1,135 @@
+package proverbs
+
+import (
+	"cmp"
+	"errors"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// sharedDir holds the templates examples share, next to the official and
+// community directories. Each file defines snippets with {{define}} for
+// examples to include with {{template "name" .}}.
+const sharedDir = "shared"
+
+// ExampleData is what example templates are executed with. GoVersion is
+// the version from the example's front matter, or the version examples are
+// built with by default.
+type ExampleData struct {
+	ID        string
+	Source    Source
+	GoVersion string
+}
+
+// templateErrorPos matches the "template: name:line:" or
+// "template: name:line:col:" prefix of text/template errors
+var templateErrorPos = regexp.MustCompile(`^template: ([^:]*):(\d+):(?:\d+:)? ?`)
+
+// parseSharedTemplates parses every template in dir of fsys into one set.
+// A missing directory yields an empty set.
+func parseSharedTemplates(fsys fs.FS, dir string) (*template.Template, error) {
+	shared := template.New(sharedDir).Option("missingkey=error")
+	files, err := fs.Glob(fsys, path.Join(dir, "*"+ExampleExt))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		src, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, DataError{File: file, Message: err.Error()}
+		}
+		if _, err := shared.New(file).Parse(string(src)); err != nil {
+			return nil, templateError(file, err)
+		}
+	}
+	return shared, nil
+}
+
+// executeExample executes the example in file as a template that can use
+// the shared templates. Errors are returned as a DataError for file.
+func executeExample(shared *template.Template, file, src string, proverb Proverb) (string, error) {
+	tmpl, err := shared.Clone()
+	if err != nil {
+		return "", DataError{File: file, Message: err.Error()}
+	}
+	if _, err := tmpl.New(file).Parse(src); err != nil {
+		return "", templateError(file, err)
+	}
+
+	meta, _, _ := ParseFrontMatter(src)
+	data := ExampleData{
+		ID:        proverb.ID,
+		Source:    proverb.Source,
+		GoVersion: cmp.Or(meta.GoVersion, defaultGoVersion),
+	}
+	var out strings.Builder
+	if err := tmpl.ExecuteTemplate(&out, file, data); err != nil {
+		return "", templateError(file, err)
+	}
+	return out.String(), nil
+}
+
+// templateLines maps each line of out, an executed example, to the line of
+// its template src it came from. Lines a {{template}} action expands to map
+// to the line of the action, and lines an action changed to the line they
+// replace. It returns nil when executing left the lines unchanged.
+func templateLines(src, out string) []int {
+	if src == out {
+		return nil
+	}
+	var (
+		lines   []int
+		removed []int // template lines of the current change
+		added   int   // output lines of the current change
+		line    int   // last template line passed
+	)
+	for _, d := range DiffLines(strings.Split(src, "\n"), strings.Split(out, "\n")) {
+		switch d.Op {
+		case DiffEqual:
+			line++
+			lines = append(lines, line)
+			removed, added = removed[:0], 0
+		case DiffRemove:
+			line++
+			removed = append(removed, line)
+		case DiffAdd:
+			from := max(line, 1)
+			if len(removed) > 0 {
+				from = removed[min(added, len(removed)-1)]
+			}
+			lines = append(lines, from)
+			added++
+		}
+	}
+	return lines
+}
+
+// templateLine returns the line of the proverb's example template that
+// line of its example came from
+func (p Proverb) templateLine(line int) int {
+	if line < 1 || line > len(p.templateLines) {
+		return line
+	}
+	return p.templateLines[line-1]
+}
+
+// templateError converts a template error into a DataError for file. Its
+// line is only kept when the error is in file itself rather than in a
+// shared template.
+func templateError(file string, err error) DataError {
+	var de DataError
+	if errors.As(err, &de) {
+		return de
+	}
+	msg := err.Error()
+	m := templateErrorPos.FindStringSubmatch(msg)
+	if m == nil || m[1] != file {
+		return DataError{File: file, Message: msg}
+	}
+	line, _ := strconv.Atoi(m[2])
+	return DataError{File: file, Line: line, Message: msg[len(m[0]):]}
+}
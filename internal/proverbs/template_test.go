@@ -0,0 +1,79 @@
+package proverbs
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestTemplateLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		src, out string
+		want     []int
+	}{
+		{"unchanged", "a\nb", "a\nb", nil},
+		{"expanded", "a\n{{template \"t\"}}\nb", "a\nx\ny\nz\nb", []int{1, 2, 2, 2, 3}},
+		{"replaced in place", "a\nid := {{.ID}}\nb", "a\nid := x\nb", []int{1, 2, 3}},
+		{"several replaced", "{{.A}}\n{{.B}}\nc", "1\n2\nc", []int{1, 2, 3}},
+		{"removed", "a\n{{/* note */}}\nb", "a\n\nb", []int{1, 2, 3}},
+		{"removed entirely", "{{/* note */}}a\nb", "a\nb", []int{1, 2}},
+		{"expanded at start", "{{template \"t\"}}\nb", "x\ny\nb", []int{1, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := templateLines(tt.src, tt.out); !slices.Equal(got, tt.want) {
+				t.Errorf("templateLines(%q, %q) = %v, want %v", tt.src, tt.out, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestExampleTemplateLines checks examples that include the five-line
+// "user" snippet on line action report positions in their template rather
+// than in the expanded example
+func TestExampleTemplateLines(t *testing.T) {
+	collection, err := LoadFromFS(os.DirFS("examples"), "official", "community")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id     string
+		action int
+		errors []int // lines of the errors validation reports
+	}{
+		{"community-050", 2, nil},
+		{"community-060", 22, []int{26}},
+		{"community-079", 52, nil},
+		{"community-081", 4, []int{38}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			proverb, err := collection.Get(tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for line, want := range map[int]int{
+				tt.action - 1: tt.action - 1,
+				tt.action:     tt.action,
+				tt.action + 4: tt.action,
+				tt.action + 5: tt.action + 1,
+				tt.action + 9: tt.action + 5,
+			} {
+				if got := proverb.templateLine(line); got != want {
+					t.Errorf("templateLine(%d) = %d, want %d", line, got, want)
+				}
+			}
+
+			var lines []int
+			for _, ve := range validateProverb(tt.id, proverb) {
+				if ve.Line > 0 {
+					lines = append(lines, ve.Line)
+				}
+			}
+			if !slices.Equal(lines, tt.errors) {
+				t.Errorf("validation errors on lines %v, want %v", lines, tt.errors)
+			}
+		})
+	}
+}
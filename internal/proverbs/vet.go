@@ -0,0 +1,314 @@
+package proverbs
+
+import (
+	"fmt"
+	"go/ast"
+	gotoken "go/token"
+	"go/version"
+	"strings"
+)
+
+// lockTypes are the sync types that must not be copied after first use
+var lockTypes = map[string]bool{
+	"Cond":      true,
+	"Map":       true,
+	"Mutex":     true,
+	"Once":      true,
+	"Pool":      true,
+	"RWMutex":   true,
+	"WaitGroup": true,
+}
+
+// VetExample inspects an example's syntax tree for mistakes go vet would
+// report and returns them with their position in file:
+//
+//   - copylocks: a receiver or parameter passes a value holding a sync
+//     lock, such as a struct with a sync.Mutex field, by value
+//   - loopclosure: a goroutine or deferred function literal captures a
+//     loop variable, which before Go 1.22 all iterations share; it is only
+//     reported for examples declaring an earlier Go version
+//   - waitgroup: sync.WaitGroup.Add is called inside the goroutine it is
+//     meant to wait for, racing with Wait
+//
+// Examples that do not parse are not inspected.
+func VetExample(id, file, src string) []ValidationError {
+	if strings.TrimSpace(src) == "" {
+		return nil
+	}
+	layout, errs := layoutExample(id, file, src)
+	if errs != nil {
+		return nil
+	}
+	meta, _, _ := ParseFrontMatter(src)
+	layout.imports = meta.Imports
+
+	fset := gotoken.NewFileSet()
+	f, _, err := layout.parse(fset, examplePackage, "_")
+	if err != nil {
+		return nil
+	}
+
+	v := &vetter{id: id, file: file, fset: fset}
+	v.copyLocks(f)
+	if meta.GoVersion != "" && version.Compare("go"+meta.GoVersion, "go1.22") < 0 {
+		v.loopClosure(f)
+	}
+	v.waitGroupAdd(f)
+	return v.errs
+}
+
+// vetter collects the findings for one example
+type vetter struct {
+	id   string
+	file string
+	fset *gotoken.FileSet
+	errs []ValidationError
+}
+
+func (v *vetter) report(pos gotoken.Pos, check, format string, args ...any) {
+	p := v.fset.Position(pos)
+	v.errs = append(v.errs, ValidationError{
+		ProverbID: v.id,
+		Field:     "Example",
+		File:      v.file,
+		Line:      p.Line,
+		Column:    p.Column,
+		Message:   check + ": " + fmt.Sprintf(format, args...),
+	})
+}
+
+// copyLocks reports receivers and parameters that copy a lock. Struct types
+// declared in the example hold a lock when a field, embedded or not, is a
+// sync lock or another such struct.
+func (v *vetter) copyLocks(f *ast.File) {
+	structs := make(map[string]*ast.StructType)
+	ast.Inspect(f, func(n ast.Node) bool {
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				structs[spec.Name.Name] = st
+			}
+		}
+		return true
+	})
+
+	// holds maps each struct type to the lock it contains, "" if none
+	holds := make(map[string]string)
+	var lockIn func(expr ast.Expr, seen map[string]bool) string
+	lockIn = func(expr ast.Expr, seen map[string]bool) string {
+		switch t := expr.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "sync" && lockTypes[t.Sel.Name] {
+				return "sync." + t.Sel.Name
+			}
+		case *ast.Ident:
+			st, ok := structs[t.Name]
+			if !ok || seen[t.Name] {
+				return ""
+			}
+			if lock, ok := holds[t.Name]; ok {
+				return lock
+			}
+			seen[t.Name] = true
+			for _, field := range st.Fields.List {
+				if lock := lockIn(field.Type, seen); lock != "" {
+					holds[t.Name] = lock
+					return lock
+				}
+			}
+			holds[t.Name] = ""
+		case *ast.ArrayType:
+			if t.Len != nil {
+				return lockIn(t.Elt, seen)
+			}
+		}
+		return ""
+	}
+
+	checkFields := func(name string, fields *ast.FieldList) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			if lock := lockIn(field.Type, map[string]bool{}); lock != "" {
+				what := exprString(field.Type)
+				if what != lock {
+					what += " contains " + lock
+				}
+				v.report(field.Type.Pos(), "copylocks", "%s passes lock by value: %s", name, what)
+			}
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch fn := n.(type) {
+		case *ast.FuncDecl:
+			checkFields(fn.Name.Name, fn.Recv)
+			checkFields(fn.Name.Name, fn.Type.Params)
+		case *ast.FuncLit:
+			checkFields("func", fn.Type.Params)
+		}
+		return true
+	})
+}
+
+// exprString returns the source form of a type expression
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[...]" + exprString(t.Elt)
+	}
+	return "value"
+}
+
+// loopClosure reports loop variables used by function literals started with
+// go or defer in the loop body
+func (v *vetter) loopClosure(f *ast.File) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		var vars []*ast.Ident
+		var body *ast.BlockStmt
+		switch loop := n.(type) {
+		case *ast.RangeStmt:
+			if loop.Tok != gotoken.DEFINE {
+				return true
+			}
+			for _, expr := range []ast.Expr{loop.Key, loop.Value} {
+				if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+					vars = append(vars, ident)
+				}
+			}
+			body = loop.Body
+		case *ast.ForStmt:
+			assign, ok := loop.Init.(*ast.AssignStmt)
+			if !ok || assign.Tok != gotoken.DEFINE {
+				return true
+			}
+			for _, expr := range assign.Lhs {
+				if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+					vars = append(vars, ident)
+				}
+			}
+			body = loop.Body
+		default:
+			return true
+		}
+
+		for _, stmt := range body.List {
+			var call *ast.CallExpr
+			switch s := stmt.(type) {
+			case *ast.GoStmt:
+				call = s.Call
+			case *ast.DeferStmt:
+				call = s.Call
+			default:
+				continue
+			}
+			lit, ok := call.Fun.(*ast.FuncLit)
+			if !ok {
+				continue
+			}
+			ast.Inspect(lit.Body, func(n ast.Node) bool {
+				ident, ok := n.(*ast.Ident)
+				if !ok {
+					return true
+				}
+				for _, loopVar := range vars {
+					if ident.Obj != nil && ident.Obj == loopVar.Obj {
+						v.report(ident.Pos(), "loopclosure", "loop variable %s captured by func literal", ident.Name)
+					}
+				}
+				return true
+			})
+		}
+		return true
+	})
+}
+
+// waitGroupAdd reports calls to Add on a sync.WaitGroup inside a function
+// literal started with go. WaitGroups are recognized by the declared type
+// of the variable, field or parameter named in the call.
+func (v *vetter) waitGroupAdd(f *ast.File) {
+	isWaitGroup := func(expr ast.Expr) bool {
+		if star, ok := expr.(*ast.StarExpr); ok {
+			expr = star.X
+		}
+		if lit, ok := expr.(*ast.CompositeLit); ok {
+			expr = lit.Type
+		}
+		sel, ok := expr.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		return ok && pkg.Name == "sync" && sel.Sel.Name == "WaitGroup"
+	}
+
+	names := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch decl := n.(type) {
+		case *ast.Field:
+			if isWaitGroup(decl.Type) {
+				for _, name := range decl.Names {
+					names[name.Name] = true
+				}
+			}
+		case *ast.ValueSpec:
+			if decl.Type != nil && isWaitGroup(decl.Type) {
+				for _, name := range decl.Names {
+					names[name.Name] = true
+				}
+			}
+		case *ast.AssignStmt:
+			for i, rhs := range decl.Rhs {
+				if unary, ok := rhs.(*ast.UnaryExpr); ok && unary.Op == gotoken.AND {
+					rhs = unary.X
+				}
+				if ident, ok := decl.Lhs[min(i, len(decl.Lhs)-1)].(*ast.Ident); ok && isWaitGroup(rhs) {
+					names[ident.Name] = true
+				}
+			}
+		}
+		return true
+	})
+	if len(names) == 0 {
+		return
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.GoStmt)
+		if !ok {
+			return true
+		}
+		lit, ok := stmt.Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return true
+		}
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if _, ok := n.(*ast.FuncLit); ok {
+				return false
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Add" {
+				return true
+			}
+			var name string
+			switch x := sel.X.(type) {
+			case *ast.Ident:
+				name = x.Name
+			case *ast.SelectorExpr:
+				name = x.Sel.Name
+			}
+			if names[name] {
+				v.report(call.Pos(), "waitgroup", "%s.Add called from inside new goroutine", name)
+			}
+			return true
+		})
+		return true
+	})
+}
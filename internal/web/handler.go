@@ -1,42 +1,137 @@
 package web
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-proverbs/go-proverbs/internal/proverbs"
 )
 
+// templatePattern matches the page templates in a handler's file system
+const templatePattern = "templates/*.html"
+
 // Handler handles web requests
 type Handler struct {
-	collection *proverbs.ProverbCollection
-	logger     *slog.Logger
-	templates  *template.Template
+	store  proverbs.Store
+	logger *slog.Logger
+	fsys   fs.FS
+	reload bool
+	static bool
+
+	mu        sync.Mutex
+	templates *template.Template
+	stamp     string
 }
 
-// NewHandler creates a new web handler
-func NewHandler(collection *proverbs.ProverbCollection, logger *slog.Logger) *Handler {
-	templates := template.Must(template.New("").Funcs(templateFuncs).ParseGlob("web/templates/*.html"))
+// NewHandler creates a new web handler rendering the templates in the
+// templates directory of fsys
+func NewHandler(store proverbs.Store, logger *slog.Logger, fsys fs.FS) *Handler {
+	templates := template.Must(parseTemplates(fsys))
 
 	return &Handler{
-		collection: collection,
-		logger:     logger,
-		templates:  templates,
+		store:     store,
+		logger:    logger,
+		fsys:      fsys,
+		templates: templates,
 	}
 }
 
+// ReloadTemplates makes the handler parse its templates again whenever one
+// is added, removed or modified, so they can be edited while it serves.
+// Call it before serving any requests.
+func (h *Handler) ReloadTemplates() {
+	h.reload = true
+	h.stamp, _ = templateStamp(h.fsys)
+}
+
+// RenderStatic makes the handler render pages for a static copy of the
+// site: controls that need the server, such as search, sorting and running
+// examples, are left out, and viewing a proverb is not counted. Call it
+// before serving any requests.
+func (h *Handler) RenderStatic() {
+	h.static = true
+}
+
+// parseTemplates parses the page templates in fsys
+func parseTemplates(fsys fs.FS) (*template.Template, error) {
+	return template.New("").Funcs(templateFuncs).ParseFS(fsys, templatePattern)
+}
+
+// templateStamp summarizes the names, sizes and modification times of the
+// page templates in fsys, changing whenever one of them does
+func templateStamp(fsys fs.FS) (string, error) {
+	files, err := fs.Glob(fsys, templatePattern)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, file := range files {
+		info, err := fs.Stat(fsys, file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", file, info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// currentTemplates returns the templates to render with, parsing them again
+// first if reloading is enabled and they changed. A template that fails to
+// parse is retried on the next request.
+func (h *Handler) currentTemplates() (*template.Template, error) {
+	if !h.reload {
+		return h.templates, nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	stamp, err := templateStamp(h.fsys)
+	if err != nil {
+		return nil, err
+	}
+	if stamp == h.stamp {
+		return h.templates, nil
+	}
+	templates, err := parseTemplates(h.fsys)
+	if err != nil {
+		return nil, err
+	}
+	h.logger.Info("reloaded templates")
+	h.templates, h.stamp = templates, stamp
+	return templates, nil
+}
+
 // HandleIndex serves the main index page
 func (h *Handler) HandleIndex(w http.ResponseWriter, r *http.Request) {
-	stats := h.collection.GetStats()
+	stats, err := h.stats()
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	
 	// Get a mix of official and community proverbs for the homepage
 	var recentProverbs []proverbs.Proverb
-	official := h.collection.GetBySource(proverbs.SourceOfficial)
-	community := h.collection.GetBySource(proverbs.SourceCommunity)
+	official, err := h.store.Query(proverbs.Query{Source: proverbs.SourceOfficial})
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
+	community, err := h.store.Query(proverbs.Query{Source: proverbs.SourceCommunity})
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	
 	// Take first 5 official and first 5 community
 	for i := 0; i < 5 && i < len(official); i++ {
@@ -51,7 +146,7 @@ func (h *Handler) HandleIndex(w http.ResponseWriter, r *http.Request) {
 		Description:  "A comprehensive collection of Go programming wisdom",
 		TemplateName: "index-content",
 		Stats:        stats,
-		Proverbs:     h.toProverbsWithID(recentProverbs),
+		Proverbs:     recentProverbs,
 		CurrentYear:  time.Now().Year(),
 	}
 
@@ -62,55 +157,61 @@ func (h *Handler) HandleIndex(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) HandleProverb(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 
-	foundProverb := h.collection.GetByID(id)
-	if foundProverb == nil {
+	foundProverb, err := h.store.Get(id)
+	if errors.Is(err, proverbs.ErrNotFound) {
 		http.NotFound(w, r)
 		return
 	}
-
-	// Create ProverbWithID for the found proverb
-	proverbWithID := &ProverbWithID{
-		Proverb: *foundProverb,
-		ID:      id,
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
+	if recorder, ok := h.store.(proverbs.ViewRecorder); ok && !h.static {
+		recorder.RecordView(id)
 	}
 
 	// Get related proverbs (same category)
-	related := h.collection.GetByCategory(foundProverb.Category)
+	related, err := h.store.Query(proverbs.Query{Category: foundProverb.Category})
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	var relatedFiltered []proverbs.Proverb
 	for _, p := range related {
-		// Skip the current proverb by comparing all fields since we don't have ID
-		if p.Title != foundProverb.Title && len(relatedFiltered) < 5 {
+		if p.ID != foundProverb.ID && len(relatedFiltered) < 5 {
 			relatedFiltered = append(relatedFiltered, p)
 		}
 	}
 
 	// Get all proverbs for navigation
-	allProverbs := h.collection.GetAll()
+	allProverbs, err := h.store.List()
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	var foundIndex int = -1
 	for i, proverb := range allProverbs {
-		if proverb.Title == foundProverb.Title && proverb.Text == foundProverb.Text {
+		if proverb.ID == foundProverb.ID {
 			foundIndex = i
 			break
 		}
 	}
 
 	// Get previous and next proverbs
-	var prevProverb, nextProverb *ProverbWithID
+	var prevProverb, nextProverb *proverbs.Proverb
 	if foundIndex > 0 {
-		prev := h.toProverbWithID(allProverbs[foundIndex-1])
-		prevProverb = &prev
+		prevProverb = &allProverbs[foundIndex-1]
 	}
 	if foundIndex >= 0 && foundIndex < len(allProverbs)-1 {
-		next := h.toProverbWithID(allProverbs[foundIndex+1])
-		nextProverb = &next
+		nextProverb = &allProverbs[foundIndex+1]
 	}
 
 	data := PageData{
 		Title:        foundProverb.Title,
 		Description:  foundProverb.Text,
 		TemplateName: "proverb-content",
-		Proverb:      proverbWithID,
-		Proverbs:     h.toProverbsWithID(relatedFiltered),
+		Proverb:      &foundProverb,
+		Proverbs:     relatedFiltered,
 		PrevProverb:  prevProverb,
 		NextProverb:  nextProverb,
 		CurrentYear:  time.Now().Year(),
@@ -121,7 +222,11 @@ func (h *Handler) HandleProverb(w http.ResponseWriter, r *http.Request) {
 
 // HandleCategories serves the categories overview page
 func (h *Handler) HandleCategories(w http.ResponseWriter, r *http.Request) {
-	stats := h.collection.GetStats()
+	stats, err := h.stats()
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 
 	h.logger.Info("handling categories request", "path", r.URL.Path, "stats_categories", len(stats.Categories))
 	for category, count := range stats.Categories {
@@ -145,24 +250,26 @@ func (h *Handler) HandleCategory(w http.ResponseWriter, r *http.Request) {
 	category := proverbs.Category(categoryStr)
 
 	h.logger.Info("handling category request", "category_str", categoryStr, "category", category, "path", r.URL.Path)
+	sort, ok := h.parseSort(w, r)
+	if !ok {
+		return
+	}
 
-	categoryProverbs := h.collection.GetByCategory(category)
-	h.logger.Info("category proverbs found", "category", category, "count", len(categoryProverbs))
-
-	// Debug: log all available categories
-	allProverbs := h.collection.GetAll()
-	categoryMap := make(map[string]int)
-	for _, p := range allProverbs {
-		categoryMap[string(p.Category)]++
+	categoryProverbs, err := h.store.Query(proverbs.Query{Category: category, Sort: sort})
+	if err != nil {
+		h.serverError(w, err)
+		return
 	}
-	h.logger.Info("all available categories", "categories", categoryMap)
+	h.logger.Info("category proverbs found", "category", category, "count", len(categoryProverbs))
 
 	data := PageData{
 		Title:        fmt.Sprintf("%s - Go Proverbs", strings.Title(string(category))),
 		Description:  fmt.Sprintf("Go proverbs about %s", category),
 		TemplateName: "category-content",
 		Category:     string(category),
-		Proverbs:     h.toProverbsWithID(categoryProverbs),
+		Sort:         sort,
+		SortFields:   proverbs.SortFields,
+		Proverbs:     categoryProverbs,
 		CurrentYear:  time.Now().Year(),
 	}
 
@@ -171,7 +278,11 @@ func (h *Handler) HandleCategory(w http.ResponseWriter, r *http.Request) {
 
 // HandleTags displays all available tags
 func (h *Handler) HandleTags(w http.ResponseWriter, r *http.Request) {
-	stats := h.collection.GetStats()
+	stats, err := h.stats()
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	
 	data := PageData{
 		Title:        "All Tags - Go Proverbs",
@@ -187,16 +298,31 @@ func (h *Handler) HandleTags(w http.ResponseWriter, r *http.Request) {
 // HandleTag displays proverbs for a specific tag
 func (h *Handler) HandleTag(w http.ResponseWriter, r *http.Request) {
 	tag := r.PathValue("tag")
+	sort, ok := h.parseSort(w, r)
+	if !ok {
+		return
+	}
 	
-	proverbList := h.collection.GetByTag(tag)
+	proverbList, err := h.store.Query(proverbs.Query{Tag: tag, Sort: sort})
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
+	stats, err := h.stats()
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 	
 	data := PageData{
 		Title:        fmt.Sprintf("Tag: %s - Go Proverbs", tag),
 		Description:  fmt.Sprintf("Go proverbs tagged with %s", tag),
 		TemplateName: "tag-content",
 		Tag:          tag,
-		Proverbs:     h.toProverbsWithID(proverbList),
-		Stats:        h.collection.GetStats(),
+		Sort:         sort,
+		SortFields:   proverbs.SortFields,
+		Proverbs:     proverbList,
+		Stats:        stats,
 		CurrentYear:  time.Now().Year(),
 	}
 	
@@ -207,15 +333,25 @@ func (h *Handler) HandleTag(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) HandleSource(w http.ResponseWriter, r *http.Request) {
 	sourceStr := r.PathValue("source")
 	source := proverbs.Source(sourceStr)
+	sort, ok := h.parseSort(w, r)
+	if !ok {
+		return
+	}
 
-	sourceProverbs := h.collection.GetBySource(source)
+	sourceProverbs, err := h.store.Query(proverbs.Query{Source: source, Sort: sort})
+	if err != nil {
+		h.serverError(w, err)
+		return
+	}
 
 	data := PageData{
 		Title:        fmt.Sprintf("%s Proverbs - Go Proverbs", strings.Title(string(source))),
 		Description:  fmt.Sprintf("%s Go proverbs", strings.Title(string(source))),
 		TemplateName: "source-content",
 		Source:       string(source),
-		Proverbs:     h.toProverbsWithID(sourceProverbs),
+		Sort:         sort,
+		SortFields:   proverbs.SortFields,
+		Proverbs:     sourceProverbs,
 		CurrentYear:  time.Now().Year(),
 	}
 
@@ -225,10 +361,34 @@ func (h *Handler) HandleSource(w http.ResponseWriter, r *http.Request) {
 // HandleSearch serves search results
 func (h *Handler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
-	var results []proverbs.Proverb
+	search := &proverbs.SearchResponse{}
+	sort, err := proverbs.ParseSearchSort(r.URL.Query().Get("sort"), r.URL.Query().Get("order"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
+	filter, err := proverbs.ParseFacetFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status := http.StatusOK
+	var queryErr *proverbs.QueryError
+	var facets []FacetView
 	if query != "" {
-		results = h.collection.SearchProverbs(query)
+		search, err = proverbs.Search(h.store, query)
+		if errors.As(err, &queryErr) {
+			status = http.StatusBadRequest
+			search = &proverbs.SearchResponse{}
+		} else if err != nil {
+			h.serverError(w, err)
+			return
+		}
+		facets = facetViews(r.URL, proverbs.ComputeFacets(search.Results, filter), filter)
+		search.Results = proverbs.FilterResults(search.Results, filter)
+		proverbs.SortResults(search.Results, sort)
 	}
 
 	data := PageData{
@@ -236,71 +396,154 @@ func (h *Handler) HandleSearch(w http.ResponseWriter, r *http.Request) {
 		Description:  fmt.Sprintf("Search results for '%s'", query),
 		TemplateName: "search-content",
 		Query:        query,
-		Proverbs:     h.toProverbsWithID(results),
+		Sort:         sort,
+		SortFields:   proverbs.SearchSortFields,
+		Results:      search.Results,
+		Facets:       facets,
+		Filters:      filter,
+		Suggestions:  search.Suggestions,
+		QueryError:   queryErr,
 		CurrentYear:  time.Now().Year(),
 	}
 
-	h.renderTemplate(w, "search.html", data)
+	h.renderTemplateStatus(w, "search.html", status, data)
 }
 
 // HandleRandom serves a random proverb
 func (h *Handler) HandleRandom(w http.ResponseWriter, r *http.Request) {
-	randomProverb := h.collection.GetRandomProverb()
-	// Find the ID by searching through the maps
-	var proverbID string
-	for id, proverb := range h.collection.Official {
-		if proverb.Title == randomProverb.Title && proverb.Text == randomProverb.Text {
-			proverbID = id
-			break
-		}
+	all, err := h.store.List()
+	if err != nil {
+		h.serverError(w, err)
+		return
 	}
-	if proverbID == "" {
-		for id, proverb := range h.collection.Community {
-			if proverb.Title == randomProverb.Title && proverb.Text == randomProverb.Text {
-				proverbID = id
-				break
+	if len(all) == 0 {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+	http.Redirect(w, r, "/proverbs/"+all[rand.IntN(len(all))].ID, http.StatusFound)
+}
+
+// FacetView is a facet as shown beside search results
+type FacetView struct {
+	Field  proverbs.FacetField
+	Values []FacetOption
+}
+
+// FacetOption is a facet value with a link that toggles it as a filter
+type FacetOption struct {
+	proverbs.FacetValue
+	Selected bool
+	URL      string
+}
+
+// facetViews builds the facet sidebar for a search page. Every link keeps
+// the query, sort and other filters, adding or removing one value.
+func facetViews(u *url.URL, facets proverbs.Facets, filter proverbs.FacetFilter) []FacetView {
+	var views []FacetView
+	for _, field := range proverbs.FacetFields {
+		if len(facets[field]) == 0 {
+			continue
+		}
+		view := FacetView{Field: field}
+		for _, value := range facets[field] {
+			params := u.Query()
+			selected := filter.Has(field, value.Value)
+			if selected {
+				params[string(field)] = slices.DeleteFunc(params[string(field)], func(v string) bool {
+					return strings.TrimSpace(v) == value.Value
+				})
+			} else {
+				params.Add(string(field), value.Value)
 			}
+			view.Values = append(view.Values, FacetOption{
+				FacetValue: value,
+				Selected:   selected,
+				URL:        "/search?" + params.Encode(),
+			})
 		}
+		views = append(views, view)
 	}
-	http.Redirect(w, r, "/proverbs/"+proverbID, http.StatusFound)
+	return views
 }
 
-// ProverbWithID wraps a proverb with its ID for template use
-type ProverbWithID struct {
-	proverbs.Proverb
-	ID string `json:"id"`
+// ExampleBlock is a row of example sections: a bad variant beside the good
+// one following it, or a single section
+type ExampleBlock struct {
+	Sections []SectionView
 }
 
-// Helper function to find proverb ID
-func (h *Handler) findProverbID(proverb proverbs.Proverb) string {
-	for id, p := range h.collection.Official {
-		if p.Title == proverb.Title && p.Text == proverb.Text {
-			return id
-		}
+// SectionView is an example section split into numbered lines
+type SectionView struct {
+	proverbs.ExampleSection
+	Lines []SectionLine
+}
+
+// SectionLine is a line of an example section. Code is the line with its
+// syntax highlighted. Changed marks lines that differ from the variant shown
+// beside it.
+type SectionLine struct {
+	Number  int
+	Text    string
+	Code    template.HTML
+	Changed bool
+}
+
+// exampleBlocks lays out an example's sections, pairing each bad section
+// with a good section that directly follows it. Paired lines are compared
+// ignoring indentation.
+func exampleBlocks(example *proverbs.Example) []ExampleBlock {
+	if example == nil {
+		return nil
 	}
-	for id, p := range h.collection.Community {
-		if p.Title == proverb.Title && p.Text == proverb.Text {
-			return id
+	var blocks []ExampleBlock
+	sections := example.Sections
+	for i := 0; i < len(sections); i++ {
+		if sections[i].Kind == proverbs.SectionBad && i+1 < len(sections) && sections[i+1].Kind == proverbs.SectionGood {
+			bad, good := sectionView(sections[i]), sectionView(sections[i+1])
+			markChanges(bad.Lines, good.Lines)
+			blocks = append(blocks, ExampleBlock{Sections: []SectionView{bad, good}})
+			i++
+			continue
 		}
+		blocks = append(blocks, ExampleBlock{Sections: []SectionView{sectionView(sections[i])}})
 	}
-	return ""
+	return blocks
 }
 
-// Helper function to convert proverb to ProverbWithID
-func (h *Handler) toProverbWithID(proverb proverbs.Proverb) ProverbWithID {
-	return ProverbWithID{
-		Proverb: proverb,
-		ID:      h.findProverbID(proverb),
+// sectionView highlights a section's lines, numbering them from the line it
+// starts on
+func sectionView(section proverbs.ExampleSection) SectionView {
+	view := SectionView{ExampleSection: section}
+	if section.Code == "" {
+		return view
 	}
+	view.Lines = codeLines(section.Code, section.Line)
+	return view
 }
 
-// Helper function to convert slice of proverbs to ProverbWithID
-func (h *Handler) toProverbsWithID(proverbs []proverbs.Proverb) []ProverbWithID {
-	result := make([]ProverbWithID, len(proverbs))
-	for i, proverb := range proverbs {
-		result[i] = h.toProverbWithID(proverb)
+// markChanges marks the lines removed from bad and added in good
+func markChanges(bad, good []SectionLine) {
+	trimmed := func(lines []SectionLine) []string {
+		texts := make([]string, len(lines))
+		for i, line := range lines {
+			texts[i] = strings.TrimSpace(line.Text)
+		}
+		return texts
+	}
+	i, j := 0, 0
+	for _, line := range proverbs.DiffLines(trimmed(bad), trimmed(good)) {
+		switch line.Op {
+		case proverbs.DiffRemove:
+			bad[i].Changed = true
+			i++
+		case proverbs.DiffAdd:
+			good[j].Changed = true
+			j++
+		default:
+			i++
+			j++
+		}
 	}
-	return result
 }
 
 // PageData represents data passed to templates
@@ -312,18 +555,55 @@ type PageData struct {
 	Source       string
 	Tag          string
 	TemplateName string
+	Sort         proverbs.Sort
+	SortFields   []proverbs.SortField
 	Stats        proverbs.ProverbStats
-	Proverb      *ProverbWithID
-	Proverbs     []ProverbWithID
-	PrevProverb  *ProverbWithID
-	NextProverb  *ProverbWithID
+	Proverb      *proverbs.Proverb
+	Proverbs     []proverbs.Proverb
+	Results      []proverbs.SearchResult
+	Facets       []FacetView
+	Filters      proverbs.FacetFilter
+	QueryError   *proverbs.QueryError
+	Suggestions  []string
+	PrevProverb  *proverbs.Proverb
+	NextProverb  *proverbs.Proverb
 	CurrentYear  int
+	Static       bool
+}
+
+// stats computes collection statistics from the store
+func (h *Handler) stats() (proverbs.ProverbStats, error) {
+	all, err := h.store.List()
+	if err != nil {
+		return proverbs.ProverbStats{}, err
+	}
+	return proverbs.ComputeStats(all), nil
+}
+
+// parseSort reads the sort and order query parameters, responding with a
+// 400 when they are invalid
+func (h *Handler) parseSort(w http.ResponseWriter, r *http.Request) (proverbs.Sort, bool) {
+	sort, err := proverbs.ParseSort(r.URL.Query().Get("sort"), r.URL.Query().Get("order"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return proverbs.Sort{}, false
+	}
+	return sort, true
+}
+
+// serverError logs a store failure and responds with a 500
+func (h *Handler) serverError(w http.ResponseWriter, err error) {
+	h.logger.Error("store request failed", "error", err)
+	http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 }
 
 // renderTemplate renders a template with the given data
 func (h *Handler) renderTemplate(w http.ResponseWriter, tmpl string, data PageData) {
-	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	h.renderTemplateStatus(w, tmpl, http.StatusOK, data)
+}
 
+// renderTemplateStatus renders a template with the given data and status code
+func (h *Handler) renderTemplateStatus(w http.ResponseWriter, tmpl string, status int, data PageData) {
 	h.logger.Info("rendering template", "template", tmpl, "title", data.Title)
 
 	// Log key data for debugging
@@ -333,13 +613,28 @@ func (h *Handler) renderTemplate(w http.ResponseWriter, tmpl string, data PageDa
 		"stats_official", data.Stats.Official,
 		"stats_community", data.Stats.Community,
 		"stats_categories_count", len(data.Stats.Categories),
-		"proverbs_count", len(data.Proverbs))
+		"proverbs_count", len(data.Proverbs),
+		"results_count", len(data.Results))
+
+	data.Static = h.static
+	templates, err := h.currentTemplates()
+	if err != nil {
+		// Only reloading templates fails here, so show designers the error
+		h.logger.Error("template parsing failed", "error", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 
-	if err := h.templates.ExecuteTemplate(w, "base.html", data); err != nil {
+	// Render into a buffer so a failed template still yields a clean 500
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, "base.html", data); err != nil {
 		h.logger.Error("template execution failed", "template", tmpl, "error", err)
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	buf.WriteTo(w)
 
 	h.logger.Info("template rendered successfully", "template", tmpl)
 }
@@ -385,13 +680,37 @@ var templateFuncs = template.FuncMap{
 	"safeHTML": func(s string) template.HTML {
 		return template.HTML(s)
 	},
-	"formatCode": func(code string) template.HTML {
-		// Simple code formatting - replace newlines with <br> and add syntax highlighting classes
-		formatted := strings.ReplaceAll(template.HTMLEscapeString(code), "\n", "<br>")
-		formatted = strings.ReplaceAll(formatted, "\t", "&nbsp;&nbsp;&nbsp;&nbsp;")
-		return template.HTML("<pre><code class='language-go'>" + formatted + "</code></pre>")
-	},
+	"formatCode": formatCode,
 	"add": func(a, b int) int {
 		return a + b
 	},
+	"highlight": highlight,
+	"expectedOutput": func(example string) string {
+		output, _, _ := proverbs.ExpectedOutput(example)
+		return output
+	},
+	"exampleBlocks": exampleBlocks,
+	"formatSortField": func(field proverbs.SortField) string {
+		return strings.Title(strings.ReplaceAll(string(field), "_", " "))
+	},
+}
+
+// highlight escapes text and wraps each span in <mark>. Spans that overlap
+// an earlier one or fall outside the text are ignored, so the output is
+// always well-formed.
+func highlight(text string, spans []proverbs.Span) template.HTML {
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span.Start < last || span.End > len(text) || span.Start >= span.End {
+			continue
+		}
+		b.WriteString(template.HTMLEscapeString(text[last:span.Start]))
+		b.WriteString("<mark>")
+		b.WriteString(template.HTMLEscapeString(text[span.Start:span.End]))
+		b.WriteString("</mark>")
+		last = span.End
+	}
+	b.WriteString(template.HTMLEscapeString(text[last:]))
+	return template.HTML(b.String())
 }
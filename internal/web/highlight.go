@@ -0,0 +1,118 @@
+package web
+
+import (
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"html/template"
+	"strings"
+
+	"github.com/go-proverbs/go-proverbs/internal/proverbs"
+)
+
+// tokenClass returns the CSS class web/static/css/highlight.css styles a
+// token with, or "" for operators and delimiters
+func tokenClass(tok token.Token) string {
+	switch {
+	case tok == token.COMMENT:
+		return "tok-comment"
+	case tok == token.STRING, tok == token.CHAR:
+		return "tok-string"
+	case tok == token.INT, tok == token.FLOAT, tok == token.IMAG:
+		return "tok-number"
+	case tok.IsKeyword():
+		return "tok-keyword"
+	case tok == token.IDENT:
+		return "tok-ident"
+	}
+	return ""
+}
+
+// highlightGo escapes Go source and wraps its tokens in classed spans,
+// returning one fragment per line. Tokens spanning lines, such as raw
+// strings and block comments, are split so every line is well-formed on
+// its own. Source that does not scan cleanly, like a fragment cut in the
+// middle of a string, is still highlighted as far as the scanner gets.
+func highlightGo(src string) []template.HTML {
+	src = strings.ReplaceAll(src, "\r", "")
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+	var s scanner.Scanner
+	s.Init(file, []byte(src), nil, scanner.ScanComments)
+
+	var lines []template.HTML
+	var line strings.Builder
+	write := func(text, class string) {
+		for i, part := range strings.Split(text, "\n") {
+			if i > 0 {
+				lines = append(lines, template.HTML(line.String()))
+				line.Reset()
+			}
+			switch {
+			case part == "":
+			case class == "":
+				line.WriteString(template.HTMLEscapeString(part))
+			default:
+				fmt.Fprintf(&line, `<span class="%s">%s</span>`, class, template.HTMLEscapeString(part))
+			}
+		}
+	}
+
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		// Semicolons the scanner inserts at line ends are not in the source
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue
+		}
+		start := file.Offset(pos)
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		end := min(start+len(text), len(src))
+		if start < last {
+			continue
+		}
+		write(src[last:start], "")
+		write(src[start:end], tokenClass(tok))
+		last = end
+	}
+	write(src[last:], "")
+	return append(lines, template.HTML(line.String()))
+}
+
+// codeLines highlights code and numbers its lines from first
+func codeLines(code string, first int) []SectionLine {
+	texts := strings.Split(code, "\n")
+	highlighted := highlightGo(code)
+	lines := make([]SectionLine, len(texts))
+	for i, text := range texts {
+		lines[i] = SectionLine{Number: first + i, Text: text, Code: highlighted[i]}
+	}
+	return lines
+}
+
+// formatCode renders an example as a highlighted block with line numbers,
+// each line anchored as #L<n>. Front matter is left out, and the lines after
+// it keep their numbers in the example.
+func formatCode(code string) template.HTML {
+	_, skip, _ := proverbs.ParseFrontMatter(code)
+	parts := strings.SplitAfterN(code, "\n", skip+1)
+	if len(parts) <= skip {
+		return ""
+	}
+	code = parts[skip]
+
+	var b strings.Builder
+	b.WriteString(`<pre class="code"><code>`)
+	for _, line := range codeLines(strings.TrimRight(code, "\n"), skip+1) {
+		fmt.Fprintf(&b, `<span class="code-line" id="L%d"><a class="line-number" href="#L%d">%d</a>%s</span>`+"\n",
+			line.Number, line.Number, line.Number, line.Code)
+	}
+	b.WriteString(`</code></pre>`)
+	return template.HTML(b.String())
+}
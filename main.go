@@ -4,11 +4,19 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,8 +25,13 @@ import (
 )
 
 //go:embed internal/proverbs/examples/official/*.gotmpl internal/proverbs/examples/community/*.gotmpl
+//go:embed internal/proverbs/examples/official/*.yaml internal/proverbs/examples/community/*.yaml
+//go:embed internal/proverbs/examples/shared/*.gotmpl
 var exampleFS embed.FS
 
+//go:embed web/templates/*.html web/static
+var webFS embed.FS
+
 func main() {
 	// Setup structured logging
 	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
@@ -26,11 +39,21 @@ func main() {
 	}))
 	slog.SetDefault(logger)
 
-	// Set the embedded filesystem for examples
-	proverbs.SetExampleFS(exampleFS)
+	// Run a subcommand instead of serving when one is given
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+
+	dev := flag.Bool("dev", false, "serve templates and static files from web/ on disk, reloading templates when they change")
+	flag.Usage = func() {
+		printUsage(flag.CommandLine.Output())
+		fmt.Fprintln(flag.CommandLine.Output(), "\nServer flags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
 
 	// Load proverbs
-	collection := proverbs.LoadAllProverbs()
+	collection := loadCollection()
 	logger.Info("loaded proverbs", "total", len(collection.GetAll()))
 
 	// Validate collection
@@ -41,34 +64,31 @@ func main() {
 		}
 	}
 
-	// Create web handler
-	webHandler := web.NewHandler(collection, logger)
-
-	// Setup routes
-	mux := http.NewServeMux()
-
-	// Static files
-	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServer(http.Dir("web/static/"))))
+	// Use a JSON file store when configured, seeded from the loaded collection
+	var store proverbs.Store = collection
+	if storeFile := os.Getenv("PROVERBS_STORE_FILE"); storeFile != "" {
+		fileStore, err := proverbs.NewFileStore(storeFile, collection)
+		if err != nil {
+			logger.Error("failed to open proverb store", "file", storeFile, "error", err)
+			os.Exit(1)
+		}
+		logger.Info("using file store", "file", storeFile)
+		store = fileStore
+	}
 
-	// API routes
-	mux.HandleFunc("GET /api/v1/proverbs", handleGetProverbs(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/random", handleGetRandomProverb(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/search", handleSearchProverbs(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/stats", handleGetStats(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/categories/{category}", handleGetByCategory(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/sources/{source}", handleGetBySource(collection))
-	mux.HandleFunc("GET /api/v1/proverbs/tags/{tag}", handleGetByTag(collection))
+	// Create web handler
+	webFiles := webFiles(*dev)
+	webHandler := web.NewHandler(store, logger, webFiles)
+	if *dev {
+		logger.Info("dev mode: serving web files from disk", "dir", "web")
+		webHandler.ReloadTemplates()
+	}
 
-	// Web UI routes
-	mux.HandleFunc("GET /proverbs/{id}", webHandler.HandleProverb)
-	mux.HandleFunc("GET /categories", webHandler.HandleCategories)
-	mux.HandleFunc("GET /categories/{category}", webHandler.HandleCategory)
-	mux.HandleFunc("GET /tags", webHandler.HandleTags)
-	mux.HandleFunc("GET /tags/{tag}", webHandler.HandleTag)
-	mux.HandleFunc("GET /sources/{source}", webHandler.HandleSource)
-	mux.HandleFunc("GET /search", webHandler.HandleSearch)
-	mux.HandleFunc("GET /random", webHandler.HandleRandom)
-	mux.HandleFunc("GET /", webHandler.HandleIndex)
+	mux, err := newServeMux(store, webHandler, webFiles)
+	if err != nil {
+		logger.Error("failed to set up routes", "error", err)
+		os.Exit(1)
+	}
 
 	// Apply middleware
 	handler := loggingMiddleware(logger)(corsMiddleware(mux))
@@ -113,12 +133,26 @@ func main() {
 
 // API Handlers
 
-func handleGetProverbs(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleGetProverbs(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		limit := getIntParam(r, "limit", 50)
 		offset := getIntParam(r, "offset", 0)
+		sort, err := getSortParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		goVersion, err := getGoVersionParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		allProverbs := collection.GetAll()
+		allProverbs, err := store.Query(proverbs.Query{GoVersion: goVersion, Sort: sort})
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
 		total := len(allProverbs)
 
 		// Apply pagination
@@ -136,51 +170,205 @@ func handleGetProverbs(collection *proverbs.ProverbCollection) http.HandlerFunc
 			"total":    total,
 			"limit":    limit,
 			"offset":   offset,
+			"sort":     sort.Field,
+			"order":    sort.Order,
 		}
 
 		writeJSONResponse(w, response)
 	}
 }
 
-func handleGetRandomProverb(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleGetRandomProverb(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		proverb := collection.GetRandomProverb()
-		writeJSONResponse(w, proverb)
+		all, err := store.List()
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		if len(all) == 0 {
+			http.Error(w, "no proverbs available", http.StatusNotFound)
+			return
+		}
+		writeJSONResponse(w, all[rand.IntN(len(all))])
 	}
 }
 
-func handleSearchProverbs(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleSearchProverbs(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		query := r.URL.Query().Get("q")
 		if query == "" {
 			http.Error(w, "query parameter 'q' is required", http.StatusBadRequest)
 			return
 		}
+		sort, err := proverbs.ParseSearchSort(r.URL.Query().Get("sort"), r.URL.Query().Get("order"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter, err := proverbs.ParseFacetFilter(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		goVersion, err := getGoVersionParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		search, err := proverbs.Search(store, query)
+		var queryErr *proverbs.QueryError
+		if errors.As(err, &queryErr) {
+			writeJSONError(w, http.StatusBadRequest, queryErr)
+			return
+		}
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		if goVersion != "" {
+			search.Results = slices.DeleteFunc(search.Results, func(result proverbs.SearchResult) bool {
+				return !proverbs.RunsOn(result.MinGoVersion(), goVersion)
+			})
+		}
+		facets := proverbs.ComputeFacets(search.Results, filter)
+		results := proverbs.FilterResults(search.Results, filter)
+		proverbs.SortResults(results, sort)
 
-		results := collection.SearchProverbs(query)
 		response := map[string]any{
 			"query":   query,
 			"results": results,
 			"count":   len(results),
+			"sort":    sort.Field,
+			"order":   sort.Order,
+			"facets":  facets,
+		}
+		if len(filter) > 0 {
+			response["filters"] = filter
+		}
+		if len(search.Suggestions) > 0 {
+			response["suggestions"] = search.Suggestions
 		}
 
 		writeJSONResponse(w, response)
 	}
 }
 
-func handleGetStats(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleSuggestProverbs(store proverbs.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query().Get("q")
+		limit := getIntParam(r, "limit", proverbs.DefaultCompletionLimit)
+		if limit < 1 || limit > 20 {
+			limit = proverbs.DefaultCompletionLimit
+		}
+
+		completions, err := proverbs.Complete(store, query, limit)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		if completions == nil {
+			completions = []proverbs.Completion{}
+		}
+
+		writeJSONResponse(w, map[string]any{
+			"query":       query,
+			"suggestions": completions,
+			"count":       len(completions),
+		})
+	}
+}
+
+func handleRunProverb(store proverbs.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Running is a simple request CORS does not preflight, so other
+		// sites' pages could otherwise use up the runner
+		if crossSite(r) {
+			writeJSONError(w, http.StatusForbidden, "examples can only be run from this site")
+			return
+		}
+
+		proverb, err := store.Get(r.PathValue("id"))
+		if errors.Is(err, proverbs.ErrNotFound) {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+
+		result, err := proverbs.RunExample(r.Context(), proverb, proverbs.RunOptions{})
+		switch {
+		case errors.Is(err, proverbs.ErrNoExample), errors.Is(err, proverbs.ErrNotRunnable):
+			writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		case errors.Is(err, proverbs.ErrNoToolchain):
+			writeJSONError(w, http.StatusServiceUnavailable, err.Error())
+			return
+		case err != nil:
+			slog.Error("running example failed", "id", proverb.ID, "error", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to run example")
+			return
+		}
+
+		writeJSONResponse(w, result)
+	}
+}
+
+func handleGetStats(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		stats := collection.GetStats()
+		all, err := store.List()
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		stats := proverbs.ComputeStats(all)
 		writeJSONResponse(w, stats)
 	}
 }
 
-func handleGetByCategory(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleGetValidation(store proverbs.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		errs, err := proverbs.Validate(store)
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
+		if errs == nil {
+			errs = []proverbs.ValidationError{}
+		}
+
+		response := map[string]any{
+			"valid":  len(errs) == 0,
+			"count":  len(errs),
+			"errors": errs,
+		}
+
+		writeJSONResponse(w, response)
+	}
+}
+
+func handleGetByCategory(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		categoryStr := r.PathValue("category")
 		category := proverbs.Category(categoryStr)
+		sort, err := getSortParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		goVersion, err := getGoVersionParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		results := collection.GetByCategory(category)
+		results, err := store.Query(proverbs.Query{Category: category, GoVersion: goVersion, Sort: sort})
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
 		response := map[string]any{
 			"category": category,
 			"proverbs": results,
@@ -191,12 +379,26 @@ func handleGetByCategory(collection *proverbs.ProverbCollection) http.HandlerFun
 	}
 }
 
-func handleGetBySource(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleGetBySource(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		sourceStr := r.PathValue("source")
 		source := proverbs.Source(sourceStr)
+		sort, err := getSortParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		goVersion, err := getGoVersionParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		results := collection.GetBySource(source)
+		results, err := store.Query(proverbs.Query{Source: source, GoVersion: goVersion, Sort: sort})
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
 		response := map[string]any{
 			"source":   source,
 			"proverbs": results,
@@ -207,11 +409,25 @@ func handleGetBySource(collection *proverbs.ProverbCollection) http.HandlerFunc
 	}
 }
 
-func handleGetByTag(collection *proverbs.ProverbCollection) http.HandlerFunc {
+func handleGetByTag(store proverbs.Store) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		tag := r.PathValue("tag")
+		sort, err := getSortParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		goVersion, err := getGoVersionParam(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-		results := collection.GetByTag(tag)
+		results, err := store.Query(proverbs.Query{Tag: tag, GoVersion: goVersion, Sort: sort})
+		if err != nil {
+			writeStoreError(w, err)
+			return
+		}
 		response := map[string]any{
 			"tag":      tag,
 			"proverbs": results,
@@ -256,6 +472,88 @@ func corsMiddleware(next http.Handler) http.Handler {
 
 // Utility functions
 
+// crossSite reports whether a browser sent the request from another site's
+// page, going by Sec-Fetch-Site or, in browsers without it, Origin
+func crossSite(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "same-origin", "none":
+		return false
+	case "":
+	default:
+		return true
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err != nil || u.Host != r.Host
+}
+
+// newServeMux routes the web UI, API and static files of webFiles
+func newServeMux(store proverbs.Store, webHandler *web.Handler, webFiles fs.FS) (*http.ServeMux, error) {
+	mux := http.NewServeMux()
+
+	// Static files
+	staticFiles, err := fs.Sub(webFiles, "static")
+	if err != nil {
+		return nil, err
+	}
+	mux.Handle("GET /static/", http.StripPrefix("/static/", http.FileServerFS(staticFiles)))
+
+	// API routes
+	mux.HandleFunc("GET /api/v1/proverbs", handleGetProverbs(store))
+	mux.HandleFunc("GET /api/v1/proverbs/random", handleGetRandomProverb(store))
+	mux.HandleFunc("GET /api/v1/proverbs/search", handleSearchProverbs(store))
+	mux.HandleFunc("GET /api/v1/proverbs/suggest", handleSuggestProverbs(store))
+	mux.HandleFunc("GET /api/v1/proverbs/stats", handleGetStats(store))
+	mux.HandleFunc("GET /api/v1/validation", handleGetValidation(store))
+	mux.HandleFunc("GET /api/v1/proverbs/categories/{category}", handleGetByCategory(store))
+	mux.HandleFunc("GET /api/v1/proverbs/sources/{source}", handleGetBySource(store))
+	mux.HandleFunc("GET /api/v1/proverbs/tags/{tag}", handleGetByTag(store))
+	mux.HandleFunc("POST /api/v1/proverbs/{id}/run", handleRunProverb(store))
+
+	// Web UI routes
+	mux.HandleFunc("GET /proverbs/{id}", webHandler.HandleProverb)
+	mux.HandleFunc("GET /categories", webHandler.HandleCategories)
+	mux.HandleFunc("GET /categories/{category}", webHandler.HandleCategory)
+	mux.HandleFunc("GET /tags", webHandler.HandleTags)
+	mux.HandleFunc("GET /tags/{tag}", webHandler.HandleTag)
+	mux.HandleFunc("GET /sources/{source}", webHandler.HandleSource)
+	mux.HandleFunc("GET /search", webHandler.HandleSearch)
+	mux.HandleFunc("GET /random", webHandler.HandleRandom)
+	mux.HandleFunc("GET /", webHandler.HandleIndex)
+
+	return mux, nil
+}
+
+// webFiles returns the web directory holding templates and static files:
+// the copy embedded in the binary, or in dev mode the one on disk
+func webFiles(dev bool) fs.FS {
+	if dev {
+		return os.DirFS("web")
+	}
+	files, err := fs.Sub(webFS, "web")
+	if err != nil {
+		panic(err)
+	}
+	return files
+}
+
+// loadCollection loads the proverbs, from PROVERBS_DATA_DIR when set and
+// the embedded data files otherwise
+func loadCollection() *proverbs.ProverbCollection {
+	// Set the embedded filesystem for examples
+	proverbs.SetExampleFS(exampleFS)
+
+	// Read proverb data files from disk instead of the embedded copy
+	if dataDir := os.Getenv("PROVERBS_DATA_DIR"); dataDir != "" {
+		proverbs.SetDataFS(os.DirFS(dataDir))
+	}
+
+	return proverbs.LoadAllProverbs()
+}
+
 func writeJSONResponse(w http.ResponseWriter, data any) {
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(data); err != nil {
@@ -263,6 +561,17 @@ func writeJSONResponse(w http.ResponseWriter, data any) {
 	}
 }
 
+func writeJSONError(w http.ResponseWriter, status int, err any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"error": err})
+}
+
+func writeStoreError(w http.ResponseWriter, err error) {
+	slog.Error("store request failed", "error", err)
+	http.Error(w, "Failed to read proverbs", http.StatusInternalServerError)
+}
+
 func getIntParam(r *http.Request, param string, defaultValue int) int {
 	valueStr := r.URL.Query().Get(param)
 	if valueStr == "" {
@@ -277,6 +586,20 @@ func getIntParam(r *http.Request, param string, defaultValue int) int {
 	return value
 }
 
+// getGoVersionParam returns the "go" parameter, the Go version results must
+// build with
+func getGoVersionParam(r *http.Request) (string, error) {
+	v := r.URL.Query().Get("go")
+	if v != "" && !proverbs.ValidGoVersion(v) {
+		return "", fmt.Errorf("invalid go version %q (expected e.g. 1.22)", v)
+	}
+	return v, nil
+}
+
+func getSortParam(r *http.Request) (proverbs.Sort, error) {
+	return proverbs.ParseSort(r.URL.Query().Get("sort"), r.URL.Query().Get("order"))
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -0,0 +1,11 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package main
+
+import "os"
+
+// ttyWidth reports no terminal width where it cannot be queried, leaving
+// terminalWidth to fall back to $COLUMNS or its default
+func ttyWidth(f *os.File) int {
+	return 0
+}
@@ -15,6 +15,9 @@ function initializeApp() {
     // Initialize share functionality
     initializeShareButtons();
     
+    // Initialize example run buttons
+    initializeRunButtons();
+    
     // Initialize keyboard shortcuts
     initializeKeyboardShortcuts();
     
@@ -36,8 +39,13 @@ function initializeSearch() {
             searchInput.focus();
         }
         
-        // Search suggestions (simple implementation)
+        // Autocomplete suggestions as the user types
         searchInput.addEventListener('input', debounce(handleSearchInput, 300));
+        searchInput.addEventListener('keydown', handleSuggestionKeys);
+        searchInput.addEventListener('blur', function() {
+            // Let a click on a suggestion land before hiding the list
+            setTimeout(hideSuggestions, 150);
+        });
         
         // Clear search functionality
         const clearButton = document.querySelector('.search-clear');
@@ -60,13 +68,122 @@ function initializeSearch() {
     }
 }
 
+let suggestionRequest = null;
+
 function handleSearchInput(event) {
     const query = event.target.value.trim();
-    if (query.length < 2) return;
+    if (query.length < 2) {
+        hideSuggestions();
+        return;
+    }
+    
+    // Drop the answer to an older query if it arrives late
+    if (suggestionRequest) suggestionRequest.abort();
+    suggestionRequest = new AbortController();
+    
+    fetch('/api/v1/proverbs/suggest?q=' + encodeURIComponent(query), { signal: suggestionRequest.signal })
+        .then(response => response.ok ? response.json() : { suggestions: [] })
+        .then(data => showSuggestions(data.suggestions || []))
+        .catch(err => {
+            if (err.name !== 'AbortError') {
+                console.error('Failed to fetch suggestions:', err);
+            }
+        });
+}
+
+function suggestionURL(suggestion) {
+    switch (suggestion.kind) {
+        case 'title':
+            return '/proverbs/' + encodeURIComponent(suggestion.id);
+        case 'tag':
+            return '/tags/' + encodeURIComponent(suggestion.value);
+        case 'category':
+            return '/categories/' + encodeURIComponent(suggestion.value);
+    }
+    return '/search?q=' + encodeURIComponent(suggestion.value);
+}
+
+function showSuggestions(suggestions) {
+    const list = document.getElementById('search-suggestions');
+    const searchInput = document.querySelector('.search-input');
+    if (!list || !searchInput) return;
+    
+    list.replaceChildren();
+    if (suggestions.length === 0) {
+        hideSuggestions();
+        return;
+    }
+    
+    suggestions.forEach((suggestion, i) => {
+        const item = document.createElement('li');
+        item.id = 'search-suggestion-' + i;
+        item.setAttribute('role', 'option');
+        item.setAttribute('aria-selected', 'false');
+        item.dataset.url = suggestionURL(suggestion);
+        
+        const value = document.createElement('span');
+        value.textContent = suggestion.value;
+        const kind = document.createElement('span');
+        kind.className = 'suggestion-kind';
+        kind.textContent = suggestion.kind;
+        item.append(value, kind);
+        
+        item.addEventListener('mousedown', function(e) {
+            e.preventDefault();
+            window.location.href = item.dataset.url;
+        });
+        list.appendChild(item);
+    });
     
-    // Simple search suggestions could be implemented here
-    // For now, we'll just update the URL without making requests
-    console.log('Search query:', query);
+    list.hidden = false;
+    searchInput.setAttribute('aria-expanded', 'true');
+}
+
+function hideSuggestions() {
+    const list = document.getElementById('search-suggestions');
+    const searchInput = document.querySelector('.search-input');
+    if (list) {
+        list.hidden = true;
+        list.replaceChildren();
+    }
+    if (searchInput) {
+        searchInput.setAttribute('aria-expanded', 'false');
+        searchInput.removeAttribute('aria-activedescendant');
+    }
+}
+
+function handleSuggestionKeys(event) {
+    const list = document.getElementById('search-suggestions');
+    if (!list || list.hidden) return;
+    
+    const items = Array.from(list.children);
+    const current = items.findIndex(item => item.getAttribute('aria-selected') === 'true');
+    let next = current;
+    
+    switch (event.key) {
+        case 'ArrowDown':
+            next = (current + 1) % items.length;
+            break;
+        case 'ArrowUp':
+            next = current <= 0 ? items.length - 1 : current - 1;
+            break;
+        case 'Enter':
+            // Without a highlighted suggestion Enter runs a normal search
+            if (current >= 0) {
+                event.preventDefault();
+                window.location.href = items[current].dataset.url;
+            }
+            return;
+        case 'Escape':
+            hideSuggestions();
+            return;
+        default:
+            return;
+    }
+    
+    event.preventDefault();
+    items.forEach((item, i) => item.setAttribute('aria-selected', i === next ? 'true' : 'false'));
+    event.target.setAttribute('aria-activedescendant', items[next].id);
 }
 
 // Copy Functionality
@@ -163,6 +280,64 @@ function fallbackShare(shareData) {
     copyToClipboard(shareText, document.activeElement);
 }
 
+// Example Runner
+function initializeRunButtons() {
+    document.addEventListener('click', function(e) {
+        const button = e.target.closest('[data-run]');
+        if (button) {
+            runExample(button.getAttribute('data-run'), button);
+        }
+    });
+}
+
+function runExample(id, button) {
+    const section = button.closest('section');
+    const output = section.querySelector('.run-output');
+    const status = output.querySelector('.run-status');
+    const stdout = output.querySelector('.run-stdout');
+    const stderr = output.querySelector('.run-stderr');
+    
+    button.disabled = true;
+    output.hidden = false;
+    status.textContent = 'Running…';
+    stdout.textContent = '';
+    stderr.textContent = '';
+    stdout.hidden = true;
+    stderr.hidden = true;
+    
+    fetch('/api/v1/proverbs/' + encodeURIComponent(id) + '/run', { method: 'POST' })
+        .then(response => response.json().then(data => ({ ok: response.ok, data })))
+        .then(({ ok, data }) => {
+            if (!ok) {
+                status.textContent = 'Could not run example: ' + (data.error || 'unknown error');
+                return;
+            }
+            
+            let summary = data.stage === 'build' ? 'Build failed' : 'Exited with status ' + data.exit_code;
+            if (data.timed_out) summary = 'Timed out';
+            summary += ' in ' + data.duration_ms + ' ms';
+            if (data.truncated) summary += ' (output truncated)';
+            if (data.passed === true) summary += ' ✓ matches expected output';
+            if (data.passed === false) summary += ' ✗ differs from expected output';
+            status.textContent = summary;
+            
+            stdout.textContent = data.stdout;
+            stdout.hidden = !data.stdout;
+            stderr.textContent = data.stderr;
+            stderr.hidden = !data.stderr;
+            if (!data.stdout && !data.stderr) {
+                stdout.textContent = '(no output)';
+                stdout.hidden = false;
+            }
+        })
+        .catch(err => {
+            status.textContent = 'Could not run example: ' + err.message;
+        })
+        .finally(() => {
+            button.disabled = false;
+        });
+}
+
 // Keyboard Shortcuts
 function initializeKeyboardShortcuts() {
     document.addEventListener('keydown', function(e) {
@@ -186,12 +361,15 @@ function initializeKeyboardShortcuts() {
         
         // R for random proverb
         if (e.key === 'r' && !e.ctrlKey && !e.metaKey && !isInputFocused()) {
-            window.location.href = '/random';
+            // The nav link knows the path a static copy is served from
+            const random = document.querySelector('nav a[href$="/random"]');
+            window.location.href = random ? random.href : '/random';
         }
         
         // H for home
         if (e.key === 'h' && !e.ctrlKey && !e.metaKey && !isInputFocused()) {
-            window.location.href = '/';
+            const home = document.querySelector('nav h1 a');
+            window.location.href = home ? home.href : '/';
         }
     });
 }